@@ -0,0 +1,240 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind classifies provider failures so callers can decide on retry or fallback
+type ErrorKind string
+
+const (
+	ErrAuth          ErrorKind = "auth"
+	ErrRateLimit     ErrorKind = "rate_limit"
+	ErrQuota         ErrorKind = "quota_exceeded"
+	ErrContextLength ErrorKind = "context_too_long"
+	ErrContentFilter ErrorKind = "content_filtered"
+	ErrServer        ErrorKind = "server_error"
+	ErrTimeout       ErrorKind = "timeout"
+	ErrBadRequest    ErrorKind = "bad_request"
+	ErrUnknown       ErrorKind = "unknown"
+)
+
+// APIError is a classified provider error
+type APIError struct {
+	Kind       ErrorKind     `json:"kind"`
+	Provider   string        `json:"provider"`
+	StatusCode int           `json:"status,omitempty"`
+	Code       string        `json:"code,omitempty"` // provider error type or code
+	Message    string        `json:"message"`
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("%s %s (status %d): %s", e.Provider, e.Kind, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", e.Provider, e.Kind, e.Message)
+}
+
+// Retryable reports whether the same request may succeed later
+func (e *APIError) Retryable() bool {
+	switch e.Kind {
+	case ErrRateLimit, ErrServer, ErrTimeout:
+		return true
+	}
+	return false
+}
+
+// AsAPIError extracts a classified provider error from an error chain
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// ErrorKindOf returns the error kind, or ErrUnknown for unclassified errors
+func ErrorKindOf(err error) ErrorKind {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.Kind
+	}
+	return ErrUnknown
+}
+
+// IsRetryable reports whether err is a provider error worth retrying
+func IsRetryable(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.Retryable()
+}
+
+// ExitCode returns the process exit code for an error: 130 when cancelled by a signal,
+// 10-17 per error kind and 1 for anything else
+func ExitCode(err error) int {
+	if errors.Is(err, context.Canceled) {
+		return 130
+	}
+	switch ErrorKindOf(err) {
+	case ErrAuth:
+		return 10
+	case ErrRateLimit:
+		return 11
+	case ErrQuota:
+		return 12
+	case ErrContextLength:
+		return 13
+	case ErrContentFilter:
+		return 14
+	case ErrServer:
+		return 15
+	case ErrTimeout:
+		return 16
+	case ErrBadRequest:
+		return 17
+	}
+	return 1
+}
+
+// ExitIfAPI is ExitIf for provider calls: it exits with the error kind's exit code
+// and prints the classified error as JSON on stdout when asJSON is set
+func ExitIfAPI(err error, message string, asJSON bool) {
+	if err == nil {
+		return
+	}
+
+	if asJSON {
+		apiErr, ok := AsAPIError(err)
+		if !ok {
+			apiErr = &APIError{Kind: ErrUnknown, Message: err.Error()}
+		}
+		data, _ := json.MarshalIndent(map[string]interface{}{
+			"success":   false,
+			"message":   message,
+			"error":     apiErr,
+			"retryable": apiErr.Retryable(),
+		}, "", "  ")
+		fmt.Println(string(data))
+	}
+
+	LogError("%s: %v", message, err)
+	os.Exit(ExitCode(err))
+}
+
+// providerErrorBody covers the OpenAI, Perplexity, Anthropic and Gemini error shapes
+type providerErrorBody struct {
+	Error struct {
+		Message string      `json:"message"`
+		Type    string      `json:"type"`
+		Code    interface{} `json:"code"` // string (OpenAI) or number (Gemini)
+		Status  string      `json:"status"`
+	} `json:"error"`
+}
+
+// classifyHTTPError parses a non-200 provider response into an APIError
+func classifyHTTPError(provider string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{Provider: provider, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+
+	var parsed providerErrorBody
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Message != "" {
+		apiErr.Message = parsed.Error.Message
+		apiErr.Code = parsed.Error.Type
+		if code, ok := parsed.Error.Code.(string); ok && code != "" {
+			apiErr.Code = code
+		}
+		if parsed.Error.Status != "" {
+			apiErr.Code = parsed.Error.Status
+		}
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	apiErr.Kind = classifyKind(resp.StatusCode, apiErr.Code, apiErr.Message)
+	return apiErr
+}
+
+// classifyKind maps status codes, provider error codes and messages to an error kind.
+// Codes and messages are checked first because providers reuse 400/429 for several kinds.
+func classifyKind(status int, code, message string) ErrorKind {
+	code = strings.ToLower(code)
+	msg := strings.ToLower(message)
+
+	switch {
+	case code == "insufficient_quota" || code == "billing_hard_limit_reached" ||
+		strings.Contains(msg, "exceeded your current quota") || strings.Contains(msg, "credit balance is too low"):
+		return ErrQuota
+	case code == "context_length_exceeded" || code == "request_too_large" ||
+		strings.Contains(msg, "prompt is too long") || strings.Contains(msg, "maximum context length") ||
+		strings.Contains(msg, "exceeds the maximum number of tokens"):
+		return ErrContextLength
+	case code == "content_filter" || code == "content_policy_violation":
+		return ErrContentFilter
+	case code == "invalid_api_key" || code == "authentication_error" || code == "permission_error" ||
+		code == "unauthenticated" || code == "permission_denied" || strings.Contains(msg, "api key not valid"):
+		return ErrAuth
+	case code == "rate_limit_exceeded" || code == "rate_limit_error" || code == "resource_exhausted":
+		return ErrRateLimit
+	case code == "overloaded_error" || code == "unavailable" || code == "internal":
+		return ErrServer
+	case code == "deadline_exceeded":
+		return ErrTimeout
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimit
+	case status == http.StatusRequestEntityTooLarge:
+		return ErrContextLength
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		return ErrTimeout
+	case status >= 500:
+		return ErrServer
+	case status >= 400:
+		return ErrBadRequest
+	}
+	return ErrUnknown
+}
+
+// classifyRequestError turns transport timeouts into APIErrors and wraps anything else
+func classifyRequestError(provider string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("request cancelled: %w", err)
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &APIError{Kind: ErrTimeout, Provider: provider, Message: err.Error()}
+	}
+	return fmt.Errorf("API request failed: %v", err)
+}
+
+// classifyCLIOutput classifies the output of a failed provider CLI
+func classifyCLIOutput(provider, output string) *APIError {
+	message := strings.TrimSpace(output)
+	msg := strings.ToLower(message)
+
+	kind := classifyKind(0, "", message)
+	if kind == ErrUnknown {
+		switch {
+		case strings.Contains(msg, "401") || strings.Contains(msg, "unauthorized") || strings.Contains(msg, "api key"):
+			kind = ErrAuth
+		case strings.Contains(msg, "429") || strings.Contains(msg, "rate limit"):
+			kind = ErrRateLimit
+		case strings.Contains(msg, "timed out") || strings.Contains(msg, "timeout"):
+			kind = ErrTimeout
+		case strings.Contains(msg, "500") || strings.Contains(msg, "502") || strings.Contains(msg, "503"):
+			kind = ErrServer
+		}
+	}
+
+	return &APIError{Kind: kind, Provider: provider, Message: message}
+}
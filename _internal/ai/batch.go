@@ -0,0 +1,270 @@
+package ai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BatchItem is one input of a batch run
+type BatchItem struct {
+	ID    string `json:"id"`
+	Input string `json:"input"`
+}
+
+// BatchResult pairs a batch input with its response or error
+type BatchResult struct {
+	ID         string `json:"id"`
+	Input      string `json:"input"`
+	Response   string `json:"response,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ErrorKind  string `json:"error_kind,omitempty"`
+	Model      string `json:"model,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// BatchOptions controls concurrency, rate limiting and message templating
+type BatchOptions struct {
+	Concurrency   int    // parallel requests (default 1)
+	RatePerMinute int    // request start limit, 0 = unlimited
+	Template      string // message template, {{input}} is replaced by the item input
+	Retries       int    // retries for rate limit, server and timeout errors
+}
+
+// ReadBatchItems reads a JSONL file of {"id": ..., "input": ...} objects or JSON strings.
+// Items without an id are numbered by line.
+func ReadBatchItems(path string) ([]BatchItem, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch file: %v", err)
+	}
+	defer file.Close()
+
+	var items []BatchItem
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		item, err := parseBatchLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		if item.ID == "" {
+			item.ID = strconv.Itoa(lineNo)
+		}
+		if seen[item.ID] {
+			return nil, fmt.Errorf("line %d: duplicate id %q", lineNo, item.ID)
+		}
+		seen[item.ID] = true
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %v", err)
+	}
+
+	return items, nil
+}
+
+// parseBatchLine accepts a JSON string or an object with input/text and optional id
+func parseBatchLine(line string) (BatchItem, error) {
+	var text string
+	if err := json.Unmarshal([]byte(line), &text); err == nil {
+		return BatchItem{Input: text}, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &obj); err != nil {
+		return BatchItem{}, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	item := BatchItem{}
+	if id, ok := obj["id"]; ok {
+		item.ID = fmt.Sprint(id)
+	}
+	for _, key := range []string{"input", "text"} {
+		if v, ok := obj[key].(string); ok {
+			item.Input = v
+			break
+		}
+	}
+	if item.Input == "" {
+		return BatchItem{}, fmt.Errorf("missing \"input\" field")
+	}
+	return item, nil
+}
+
+// ResumeBatchOutput prepares an output file for a resumed run and returns the ids that already
+// have a response. Failed results and repeated ids are dropped from the file, so the retried
+// items don't end up in it twice.
+func ResumeBatchOutput(path string) (map[string]bool, error) {
+	done := map[string]bool{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output file: %v", err)
+	}
+
+	var kept []string
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for _, line := range lines {
+		var result BatchResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			continue // tolerate a truncated last line from an interrupted run
+		}
+		if result.Error == "" && !done[result.ID] {
+			done[result.ID] = true
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) == len(lines) {
+		return done, nil
+	}
+
+	// Rewrite through a temp file so an interrupted rewrite keeps the previous results
+	content := strings.Join(kept, "\n")
+	if len(kept) > 0 {
+		content += "\n"
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to rewrite output file: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to rewrite output file: %v", err)
+	}
+	return done, nil
+}
+
+// RunBatch sends every item through its own isolated request using a bounded worker pool.
+// newClient is called once per worker; emit is called serially as results complete.
+// Cancelling ctx stops dispatching and aborts in-flight requests; unfinished items are not emitted.
+func RunBatch(ctx context.Context, items []BatchItem, newClient func() (ProviderClient, error), opts BatchOptions, emit func(BatchResult)) error {
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	// Create clients up front so configuration errors fail fast
+	clients := make([]ProviderClient, workers)
+	for i := range clients {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+		clients[i] = client
+	}
+
+	// Rate limiter hands out request slots at a fixed interval; every attempt, retries included, takes one
+	wait := func() error { return ctx.Err() }
+	if opts.RatePerMinute > 0 {
+		ticker := time.NewTicker(time.Minute / time.Duration(opts.RatePerMinute))
+		defer ticker.Stop()
+		wait = func() error {
+			select {
+			case <-ticker.C:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	jobs := make(chan BatchItem)
+	var emitMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, client := range clients {
+		wg.Add(1)
+		go func(client ProviderClient) {
+			defer wg.Done()
+			for item := range jobs {
+				result := runBatchItem(ctx, client, item, opts, wait)
+				if ctx.Err() != nil {
+					continue // interrupted results are retried on resume
+				}
+
+				emitMu.Lock()
+				emit(result)
+				emitMu.Unlock()
+			}
+		}(client)
+	}
+
+dispatch:
+	for _, item := range items {
+		select {
+		case jobs <- item:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// runBatchItem sends a single item, retrying retryable provider errors, and captures the outcome.
+// wait blocks until the rate limiter allows the next attempt.
+func runBatchItem(ctx context.Context, client ProviderClient, item BatchItem, opts BatchOptions, wait func() error) BatchResult {
+	message := item.Input
+	if opts.Template != "" {
+		message = strings.ReplaceAll(opts.Template, "{{input}}", item.Input)
+	}
+
+	if err := wait(); err != nil {
+		return BatchResult{ID: item.ID, Input: item.Input, Error: err.Error()}
+	}
+	response, info, err := SendMessageWithTiming(ctx, client, message)
+	for attempt := 1; err != nil && IsRetryable(err) && attempt <= opts.Retries; attempt++ {
+		select {
+		case <-time.After(retryDelay(err, attempt)):
+		case <-ctx.Done():
+			return BatchResult{ID: item.ID, Input: item.Input, Error: ctx.Err().Error()}
+		}
+		if err := wait(); err != nil {
+			return BatchResult{ID: item.ID, Input: item.Input, Error: err.Error()}
+		}
+		response, info, err = SendMessageWithTiming(ctx, client, message)
+	}
+
+	result := BatchResult{
+		ID:         item.ID,
+		Input:      item.Input,
+		Model:      info.Model,
+		DurationMs: info.Duration.Milliseconds(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+		result.ErrorKind = string(ErrorKindOf(err))
+	} else {
+		result.Response = response
+	}
+	return result
+}
+
+// retryDelay honours Retry-After and otherwise backs off exponentially from 2s
+func retryDelay(err error, attempt int) time.Duration {
+	if apiErr, ok := AsAPIError(err); ok && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	return time.Duration(1<<attempt) * time.Second
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 
 	"cli-go/_internal/config"
@@ -22,34 +22,55 @@ type ChatGPTConfig struct {
 
 // ChatGPTClient handles ChatGPT HTTP API integration
 type ChatGPTClient struct {
-	config ChatGPTConfig
-	apiKey string
-	client *http.Client
+	redaction
+	config  ChatGPTConfig
+	apiKey  string
+	client  *http.Client
+	persona *Persona
+	params  *GenerationParams
+	session *Session
+
+	ephemeral bool // no shell thread: each request stands alone
 }
 
 // ChatGPTRequest represents the OpenAI API request structure
 type ChatGPTRequest struct {
-	Model       string           `json:"model"`
-	Messages    []ChatGPTMessage `json:"messages"`
-	Temperature float64          `json:"temperature,omitempty"`
+	Model               string           `json:"model"`
+	Messages            []ChatGPTMessage `json:"messages"`
+	Temperature         *float64         `json:"temperature,omitempty"`
+	MaxTokens           int              `json:"max_tokens,omitempty"`
+	MaxCompletionTokens int              `json:"max_completion_tokens,omitempty"`
+	TopP                *float64         `json:"top_p,omitempty"`
+	Stop                []string         `json:"stop,omitempty"`
+	Seed                *int             `json:"seed,omitempty"`
 }
 
 // ChatGPTMessage represents a message in the conversation
 type ChatGPTMessage struct {
-	Role      string `json:"role"`
-	Content   string `json:"content"`
-	Timestamp string `json:"timestamp,omitempty"`
+	Role      string      `json:"role"`
+	Content   string      `json:"content"`
+	Timestamp string      `json:"timestamp,omitempty"`
+	Model     string      `json:"model,omitempty"` // thread file only
+	Usage     *TokenUsage `json:"usage,omitempty"` // thread file only
 }
 
 // ChatGPTResponse represents the OpenAI API response structure
 type ChatGPTResponse struct {
 	Choices []ChatGPTChoice `json:"choices"`
+	Usage   *ChatGPTUsage   `json:"usage,omitempty"`
 	Error   *ChatGPTError   `json:"error,omitempty"`
 }
 
+// ChatGPTUsage reports token counts for a completion
+type ChatGPTUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
 // ChatGPTChoice represents a response choice
 type ChatGPTChoice struct {
-	Message ChatGPTMessage `json:"message"`
+	Message      ChatGPTMessage `json:"message"`
+	FinishReason string         `json:"finish_reason"`
 }
 
 // ChatGPTError represents an API error
@@ -97,120 +118,132 @@ func NewChatGPTClientWithModel(format, model, apiKey string) *ChatGPTClient {
 }
 
 // SendMessage sends a message to ChatGPT via HTTP API
-func (c *ChatGPTClient) SendMessage(message string) (string, error) {
+func (c *ChatGPTClient) SendMessage(ctx context.Context, message string) (string, error) {
 	// Load conversation history
-	history, err := c.loadThreadHistory()
+	history, err := c.loadHistory()
 	if err != nil {
 		return "", err
 	}
 
-	// Add user message to history
-	userMessage := ChatGPTMessage{
-		Role:      "user",
-		Content:   message,
-		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
-	}
-	history = append(history, userMessage)
-
-	// Prepare request
-	reqBody := ChatGPTRequest{
-		Model:    c.config.Model,
-		Messages: history,
+	// The persona system prompt applies to this request only, the stored thread keeps its own
+	system := ""
+	if c.persona != nil {
+		system = c.persona.SystemPrompt
 	}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %v", err)
-	}
+	return c.sendWithHistory(ctx, history, system, message)
+}
 
-	req, err := http.NewRequestWithContext(
-		context.Background(),
-		"POST",
-		"https://api.openai.com/v1/chat/completions",
-		bytes.NewBuffer(jsonData),
-	)
+// SendMessageWithRoleFile sends a message using a role file
+func (c *ChatGPTClient) SendMessageWithRoleFile(ctx context.Context, roleFile, message string) (string, error) {
+	// Read role file
+	roleData, err := ReadPromptBody(roleFile)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+		return "", err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(req)
+	// Load conversation history
+	history, err := c.loadHistory()
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %v", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %v", err)
-	}
+	// Replace system message with role file content
+	history = setSystemMessage(history, roleData)
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-	}
+	return c.sendWithHistory(ctx, history, "", message)
+}
 
-	var response ChatGPTResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return "", fmt.Errorf("failed to parse response: %v", err)
+// SetPersona applies a persona's system prompt, model and temperature
+func (c *ChatGPTClient) SetPersona(persona *Persona) {
+	c.persona = persona
+	if persona != nil && persona.Model != "" {
+		c.config.Model = persona.Model
 	}
+}
 
-	if response.Error != nil {
-		return "", fmt.Errorf("ChatGPT API error: %s", response.Error.Message)
+// SetSession continues a named session instead of the shell thread
+func (c *ChatGPTClient) SetSession(session *Session) {
+	c.session = session
+	if session != nil {
+		c.config.Thread = session.Name
 	}
+}
 
-	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no response choices received")
-	}
+// SetEphemeral disables the shell thread so requests neither read nor extend it
+func (c *ChatGPTClient) SetEphemeral(ephemeral bool) {
+	c.ephemeral = ephemeral
+}
 
-	content := response.Choices[0].Message.Content
-	if content == "" {
-		return "", fmt.Errorf("empty response content")
+// loadHistory returns the session transcript or the shell thread history
+func (c *ChatGPTClient) loadHistory() ([]ChatGPTMessage, error) {
+	if c.session == nil && c.ephemeral {
+		return []ChatGPTMessage{{Role: "system", Content: DefaultSystemPrompt}}, nil
 	}
-
-	// Add assistant message to history
-	assistantMessage := ChatGPTMessage{
-		Role:      "assistant",
-		Content:   content,
-		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+	if c.session == nil {
+		return c.loadThreadHistory()
 	}
-	history = append(history, assistantMessage)
 
-	// Save updated history
-	if err := c.saveThreadHistory(history); err != nil {
-		LogError("Failed to save thread history: %v", err)
+	history := []ChatGPTMessage{{Role: "system", Content: DefaultSystemPrompt}}
+	for _, m := range c.session.Messages {
+		history = append(history, ChatGPTMessage{Role: m.Role, Content: m.Content})
 	}
+	return history, nil
+}
 
-	return content, nil
+// SetParams sets the generation parameters for subsequent requests
+func (c *ChatGPTClient) SetParams(params *GenerationParams) {
+	c.params = params
 }
 
-// SendMessageWithRoleFile sends a message using a role file
-func (c *ChatGPTClient) SendMessageWithRoleFile(roleFile, message string) (string, error) {
-	// Read role file
-	roleData, err := os.ReadFile(roleFile)
-	if err != nil {
-		return "", fmt.Errorf("failed to read role file: %v", err)
+// applyParams maps generation parameters to the OpenAI request schema
+func (c *ChatGPTClient) applyParams(req *ChatGPTRequest) error {
+	p := c.params
+	if p == nil {
+		return nil
+	}
+
+	// Reasoning models use fixed sampling and max_completion_tokens
+	if isOpenAIReasoningModel(req.Model) {
+		for _, param := range []string{"temperature", "top-p", "stop"} {
+			if p.isSet(param) {
+				if err := p.unsupported("OpenAI", req.Model, param, "reasoning models use fixed sampling"); err != nil {
+					return err
+				}
+			}
+		}
+		req.MaxCompletionTokens = p.MaxTokens
+		req.Seed = p.Seed
+		return nil
 	}
 
-	// Load conversation history
-	history, err := c.loadThreadHistory()
-	if err != nil {
-		return "", err
+	if len(p.Stop) > 4 {
+		return fmt.Errorf("OpenAI accepts at most 4 stop sequences, got %d", len(p.Stop))
 	}
 
-	// Replace system message with role file content
-	if len(history) > 0 && history[0].Role == "system" {
-		history[0].Content = string(roleData)
-	} else {
-		// Prepend system message if it doesn't exist
-		systemMessage := ChatGPTMessage{
-			Role:      "system",
-			Content:   string(roleData),
-			Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+	req.Temperature = p.Temperature
+	req.MaxTokens = p.MaxTokens
+	req.TopP = p.TopP
+	req.Stop = p.Stop
+	req.Seed = p.Seed
+	return nil
+}
+
+// isOpenAIReasoningModel reports whether the model is an o-series or gpt-5 reasoning model
+func isOpenAIReasoningModel(model string) bool {
+	for _, prefix := range []string{"o1", "o3", "o4", "gpt-5"} {
+		if strings.HasPrefix(model, prefix) {
+			return true
 		}
-		history = append([]ChatGPTMessage{systemMessage}, history...)
 	}
+	return false
+}
+
+// sendWithHistory appends the user message, calls the API and saves the updated history; a
+// non-empty system prompt replaces the system message in the request but not in the history
+func (c *ChatGPTClient) sendWithHistory(ctx context.Context, history []ChatGPTMessage, system, message string) (string, error) {
+	// Mask secrets before they leave the machine
+	message = c.redact(message)
 
 	// Add user message to history
 	userMessage := ChatGPTMessage{
@@ -220,10 +253,24 @@ func (c *ChatGPTClient) SendMessageWithRoleFile(roleFile, message string) (strin
 	}
 	history = append(history, userMessage)
 
-	// Prepare request
+	// Prepare request; thread bookkeeping fields are not sent to the API
+	var messages []ChatGPTMessage
+	for _, m := range history {
+		messages = append(messages, ChatGPTMessage{Role: m.Role, Content: m.Content})
+	}
+	if system != "" {
+		if len(messages) > 0 && messages[0].Role == "system" {
+			messages[0].Content = system
+		} else {
+			messages = append([]ChatGPTMessage{{Role: "system", Content: system}}, messages...)
+		}
+	}
 	reqBody := ChatGPTRequest{
 		Model:    c.config.Model,
-		Messages: history,
+		Messages: messages,
+	}
+	if err := c.applyParams(&reqBody); err != nil {
+		return "", err
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -232,7 +279,7 @@ func (c *ChatGPTClient) SendMessageWithRoleFile(roleFile, message string) (strin
 	}
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		"POST",
 		"https://api.openai.com/v1/chat/completions",
 		bytes.NewBuffer(jsonData),
@@ -246,7 +293,7 @@ func (c *ChatGPTClient) SendMessageWithRoleFile(roleFile, message string) (strin
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %v", err)
+		return "", classifyRequestError("OpenAI", err)
 	}
 	defer resp.Body.Close()
 
@@ -256,7 +303,7 @@ func (c *ChatGPTClient) SendMessageWithRoleFile(roleFile, message string) (strin
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", classifyHTTPError("OpenAI", resp, body)
 	}
 
 	var response ChatGPTResponse
@@ -265,34 +312,66 @@ func (c *ChatGPTClient) SendMessageWithRoleFile(roleFile, message string) (strin
 	}
 
 	if response.Error != nil {
-		return "", fmt.Errorf("ChatGPT API error: %s", response.Error.Message)
+		return "", &APIError{Kind: classifyKind(0, response.Error.Code, response.Error.Message), Provider: "OpenAI", Code: response.Error.Code, Message: response.Error.Message}
 	}
 
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no response choices received")
 	}
 
+	if response.Choices[0].FinishReason == "content_filter" {
+		return "", &APIError{Kind: ErrContentFilter, Provider: "OpenAI", Code: "content_filter", Message: "response was blocked by the content filter"}
+	}
+
 	content := response.Choices[0].Message.Content
 	if content == "" {
 		return "", fmt.Errorf("empty response content")
 	}
 
+	var usage *TokenUsage
+	if response.Usage != nil {
+		usage = &TokenUsage{InputTokens: response.Usage.PromptTokens, OutputTokens: response.Usage.CompletionTokens}
+	}
+
 	// Add assistant message to history
 	assistantMessage := ChatGPTMessage{
 		Role:      "assistant",
 		Content:   content,
 		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		Model:     c.config.Model,
+		Usage:     usage,
 	}
 	history = append(history, assistantMessage)
 
 	// Save updated history
-	if err := c.saveThreadHistory(history); err != nil {
-		LogError("Failed to save thread history: %v", err)
+	if c.session != nil {
+		if err := c.session.Record("openai", c.config.Model, message, content, usage); err != nil {
+			LogError("Failed to save session: %v", err)
+		}
+	} else if !c.ephemeral {
+		if err := c.saveThreadHistory(history); err != nil {
+			LogError("Failed to save thread history: %v", err)
+		}
 	}
 
 	return content, nil
 }
 
+// setSystemMessage replaces the leading system message or prepends one
+func setSystemMessage(history []ChatGPTMessage, content string) []ChatGPTMessage {
+	if len(history) > 0 && history[0].Role == "system" {
+		history[0].Content = content
+		return history
+	}
+
+	systemMessage := ChatGPTMessage{
+		Role:      "system",
+		Content:   content,
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+	}
+	return append([]ChatGPTMessage{systemMessage}, history...)
+}
+
 // GetThread returns the current thread name
 func (c *ChatGPTClient) GetThread() string {
 	return c.config.Thread
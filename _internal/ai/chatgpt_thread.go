@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"cli-go/_internal/config"
+	"cli-go/_internal/sys"
 )
 
 // getHistoryDir returns the history directory from config
@@ -55,13 +56,13 @@ func createThreadFile(thread string) {
 		initialData := []ChatGPTMessage{
 			{
 				Role:      "system",
-				Content:   "You are a helpful assistant.",
+				Content:   DefaultSystemPrompt,
 				Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
 			},
 		}
 
 		data, _ := json.MarshalIndent(initialData, "", "  ")
-		os.WriteFile(threadFile, data, 0644)
+		sys.WriteFileAtomic(threadFile, data, 0644)
 	}
 }
 
@@ -88,7 +89,8 @@ func (c *ChatGPTClient) loadThreadHistory() ([]ChatGPTMessage, error) {
 	return messages, nil
 }
 
-// saveThreadHistory saves the conversation history to the thread file
+// saveThreadHistory saves the conversation history to the thread file.
+// The file is replaced atomically so an interrupted run never leaves it half written.
 func (c *ChatGPTClient) saveThreadHistory(messages []ChatGPTMessage) error {
 	historyDir := getHistoryDir()
 	threadFile := filepath.Join(historyDir, c.config.Thread+".json")
@@ -98,7 +100,7 @@ func (c *ChatGPTClient) saveThreadHistory(messages []ChatGPTMessage) error {
 		return fmt.Errorf("failed to marshal thread history: %v", err)
 	}
 
-	if err := os.WriteFile(threadFile, data, 0644); err != nil {
+	if err := sys.WriteFileAtomic(threadFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to write thread file: %v", err)
 	}
 
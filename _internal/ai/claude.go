@@ -22,16 +22,24 @@ const (
 
 // ClaudeClient handles Claude HTTP API integration
 type ClaudeClient struct {
-	model  ClaudeModel
-	apiKey string
-	client *http.Client
+	redaction
+	model   ClaudeModel
+	apiKey  string
+	client  *http.Client
+	persona *Persona
+	params  *GenerationParams
+	session *Session
 }
 
 // ClaudeRequest represents the Anthropic API request structure
 type ClaudeRequest struct {
-	Model     string          `json:"model"`
-	MaxTokens int             `json:"max_tokens"`
-	Messages  []ClaudeMessage `json:"messages"`
+	Model         string          `json:"model"`
+	MaxTokens     int             `json:"max_tokens"`
+	System        string          `json:"system,omitempty"`
+	Temperature   *float64        `json:"temperature,omitempty"`
+	TopP          *float64        `json:"top_p,omitempty"`
+	StopSequences []string        `json:"stop_sequences,omitempty"`
+	Messages      []ClaudeMessage `json:"messages"`
 }
 
 // ClaudeMessage represents a message in the conversation
@@ -42,11 +50,19 @@ type ClaudeMessage struct {
 
 // ClaudeResponse represents the Anthropic API response structure
 type ClaudeResponse struct {
-	ID      string               `json:"id"`
-	Type    string               `json:"type"`
-	Role    string               `json:"role"`
-	Content []ClaudeContentBlock `json:"content"`
-	Error   *ClaudeError         `json:"error,omitempty"`
+	ID         string               `json:"id"`
+	Type       string               `json:"type"`
+	Role       string               `json:"role"`
+	Content    []ClaudeContentBlock `json:"content"`
+	StopReason string               `json:"stop_reason"`
+	Usage      *ClaudeUsage         `json:"usage,omitempty"`
+	Error      *ClaudeError         `json:"error,omitempty"`
+}
+
+// ClaudeUsage reports token counts for a message
+type ClaudeUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
 }
 
 // ClaudeContentBlock represents a content block in the response
@@ -61,6 +77,14 @@ type ClaudeError struct {
 	Message string `json:"message"`
 }
 
+// tokenUsage converts the response usage, nil when not reported
+func (u *ClaudeUsage) tokenUsage() *TokenUsage {
+	if u == nil {
+		return nil
+	}
+	return &TokenUsage{InputTokens: u.InputTokens, OutputTokens: u.OutputTokens}
+}
+
 // NewClaudeClient creates a new Claude client
 func NewClaudeClient(model ClaudeModel, apiKey string) *ClaudeClient {
 	// Load config to get timeout setting
@@ -80,29 +104,29 @@ func NewClaudeClient(model ClaudeModel, apiKey string) *ClaudeClient {
 }
 
 // SendMessage sends a message to Claude via HTTP API
-func (c *ClaudeClient) SendMessage(message string) (string, error) {
-	// Load config to get model names
-	cfg, err := config.LoadConfig()
-	modelName := "claude-sonnet-4-5-20250929" // Default fallback
-	if err == nil {
-		if c.model == ClaudeHaiku {
-			modelName = "claude-haiku-4-5-20251001" // Default haiku fallback
-		} else {
-			modelName = cfg.AI.Models.Anthropic
+func (c *ClaudeClient) SendMessage(ctx context.Context, message string) (string, error) {
+	// Mask secrets before they leave the machine
+	message = c.redact(message)
+
+	// Replay session transcript before the new message
+	var messages []ClaudeMessage
+	if c.session != nil {
+		for _, m := range c.session.Messages {
+			messages = append(messages, ClaudeMessage{Role: m.Role, Content: m.Content})
 		}
-	} else if c.model == ClaudeHaiku {
-		modelName = "claude-haiku-4-5-20251001"
 	}
+	messages = append(messages, ClaudeMessage{Role: "user", Content: message})
 
 	reqBody := ClaudeRequest{
-		Model:     modelName,
+		Model:     c.GetModel(),
 		MaxTokens: 4096,
-		Messages: []ClaudeMessage{
-			{
-				Role:    "user",
-				Content: message,
-			},
-		},
+		Messages:  messages,
+	}
+	if c.persona != nil {
+		reqBody.System = c.persona.SystemPrompt
+	}
+	if err := c.applyParams(&reqBody); err != nil {
+		return "", err
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -111,7 +135,7 @@ func (c *ClaudeClient) SendMessage(message string) (string, error) {
 	}
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		"POST",
 		"https://api.anthropic.com/v1/messages",
 		bytes.NewBuffer(jsonData),
@@ -126,7 +150,7 @@ func (c *ClaudeClient) SendMessage(message string) (string, error) {
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %v", err)
+		return "", classifyRequestError("Anthropic", err)
 	}
 	defer resp.Body.Close()
 
@@ -136,7 +160,7 @@ func (c *ClaudeClient) SendMessage(message string) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", classifyHTTPError("Anthropic", resp, body)
 	}
 
 	var response ClaudeResponse
@@ -145,7 +169,11 @@ func (c *ClaudeClient) SendMessage(message string) (string, error) {
 	}
 
 	if response.Error != nil {
-		return "", fmt.Errorf("Claude API error: %s", response.Error.Message)
+		return "", &APIError{Kind: classifyKind(0, response.Error.Type, response.Error.Message), Provider: "Anthropic", Code: response.Error.Type, Message: response.Error.Message}
+	}
+
+	if response.StopReason == "refusal" {
+		return "", &APIError{Kind: ErrContentFilter, Provider: "Anthropic", Code: "refusal", Message: "response was stopped by the safety classifier"}
 	}
 
 	if len(response.Content) == 0 {
@@ -157,11 +185,61 @@ func (c *ClaudeClient) SendMessage(message string) (string, error) {
 		return "", fmt.Errorf("empty response content")
 	}
 
+	if c.session != nil {
+		if err := c.session.Record("anthropic", reqBody.Model, message, content, response.Usage.tokenUsage()); err != nil {
+			LogError("Failed to save session: %v", err)
+		}
+	}
+
 	return content, nil
 }
 
+// SetPersona applies a persona's system prompt, model and temperature
+func (c *ClaudeClient) SetPersona(persona *Persona) {
+	c.persona = persona
+}
+
+// SetSession continues a named provider-neutral session
+func (c *ClaudeClient) SetSession(session *Session) {
+	c.session = session
+}
+
+// SetParams sets the generation parameters for subsequent requests
+func (c *ClaudeClient) SetParams(params *GenerationParams) {
+	c.params = params
+}
+
+// applyParams maps generation parameters to the Anthropic request schema
+func (c *ClaudeClient) applyParams(req *ClaudeRequest) error {
+	p := c.params
+	if p == nil {
+		return nil
+	}
+
+	if p.Temperature != nil && *p.Temperature > 1 {
+		return fmt.Errorf("Anthropic temperature must be between 0 and 1, got %g", *p.Temperature)
+	}
+	if p.Seed != nil {
+		if err := p.unsupported("Anthropic", req.Model, "seed", "no seed parameter in the Messages API"); err != nil {
+			return err
+		}
+	}
+
+	if p.MaxTokens > 0 {
+		req.MaxTokens = p.MaxTokens
+	}
+	req.Temperature = p.Temperature
+	req.TopP = p.TopP
+	req.StopSequences = p.Stop
+	return nil
+}
+
 // GetModel returns the current model
 func (c *ClaudeClient) GetModel() string {
+	if c.persona != nil && c.persona.Model != "" {
+		return c.persona.Model
+	}
+
 	// Load config to get model names
 	cfg, err := config.LoadConfig()
 	if err == nil {
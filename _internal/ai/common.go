@@ -2,6 +2,7 @@ package ai
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -18,14 +19,11 @@ const (
 	InputInteractive InputMode = "interactive"
 )
 
-// DetectInputMode determines how input is being provided
+// DetectInputMode determines how input is being provided; call it after parsing flags,
+// so flag values like --persona terse are not taken for the message
 func DetectInputMode() InputMode {
-	// Check if there are non-flag command line arguments
-	for i := 1; i < len(os.Args); i++ {
-		arg := os.Args[i]
-		if !strings.HasPrefix(arg, "-") {
-			return InputArgs
-		}
+	if len(flag.Args()) > 0 {
+		return InputArgs
 	}
 
 	// Check if stdin is a terminal
@@ -59,17 +57,6 @@ func GetArgs() string {
 	return strings.Join(args, " ")
 }
 
-// CheckError detects common AI CLI errors
-func CheckError(output string) error {
-	output = strings.ToLower(output)
-	if strings.Contains(output, "error") ||
-		strings.Contains(output, "failed") ||
-		strings.Contains(output, "quota") {
-		return fmt.Errorf("AI CLI error detected")
-	}
-	return nil
-}
-
 // LogError writes error message to stderr
 func LogError(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
@@ -104,14 +91,14 @@ func FormatResponseInfo(info ResponseInfo) string {
 
 // AIClient interface for AI clients that can track response time
 type AIClient interface {
-	SendMessage(message string) (string, error)
+	SendMessage(ctx context.Context, message string) (string, error)
 	GetModel() string
 }
 
 // SendMessageWithTiming wraps AI client calls with response time tracking
-func SendMessageWithTiming(client AIClient, message string) (string, ResponseInfo, error) {
+func SendMessageWithTiming(ctx context.Context, client AIClient, message string) (string, ResponseInfo, error) {
 	start := time.Now()
-	response, err := client.SendMessage(message)
+	response, err := client.SendMessage(ctx, message)
 	duration := time.Since(start)
 
 	info := ResponseInfo{
@@ -5,11 +5,12 @@ import (
 	"os"
 )
 
-// ExitIf handles the most common pattern: check error and exit if not nil
+// ExitIf handles the most common pattern: check error and exit if not nil.
+// Provider errors exit with their kind's exit code (see ExitCode).
 func ExitIf(err error, message string) {
 	if err != nil {
 		LogError("%s: %v", message, err)
-		os.Exit(1)
+		os.Exit(ExitCode(err))
 	}
 }
 
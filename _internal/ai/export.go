@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Conversation is a ChatGPT thread or a named session prepared for export
+type Conversation struct {
+	Title    string           `json:"title"`
+	Source   string           `json:"source"` // "thread" or "session"
+	Name     string           `json:"name"`
+	Messages []SessionMessage `json:"messages"`
+}
+
+// ConversationInfo describes an exportable thread or session
+type ConversationInfo struct {
+	Source   string    `json:"source"`
+	Name     string    `json:"name"`
+	Messages int       `json:"messages"`
+	Updated  time.Time `json:"updated"`
+}
+
+// ListConversations returns all threads and sessions, most recently updated first
+func ListConversations() ([]ConversationInfo, error) {
+	var infos []ConversationInfo
+	for _, dir := range []struct{ source, path string }{
+		{"thread", getHistoryDir()},
+		{"session", getSessionDir()},
+	} {
+		files, err := filepath.Glob(filepath.Join(dir.path, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %v", dir.source, err)
+		}
+		for _, file := range files {
+			stat, err := os.Stat(file)
+			if err != nil {
+				continue
+			}
+			name := strings.TrimSuffix(filepath.Base(file), ".json")
+			conv, err := loadConversation(dir.source, name)
+			if err != nil {
+				continue
+			}
+			infos = append(infos, ConversationInfo{Source: dir.source, Name: name, Messages: len(conv.Messages), Updated: stat.ModTime()})
+		}
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Updated.After(infos[j].Updated) })
+	return infos, nil
+}
+
+// LoadThreadConversation loads a ChatGPT thread; an empty name picks the most recently updated thread
+func LoadThreadConversation(name string) (*Conversation, error) {
+	if name == "" {
+		infos, err := ListConversations()
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.Source == "thread" {
+				name = info.Name
+				break
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("no threads found in %s", getHistoryDir())
+		}
+	}
+	return loadConversation("thread", name)
+}
+
+// LoadSessionConversation loads a named session
+func LoadSessionConversation(name string) (*Conversation, error) {
+	return loadConversation("session", name)
+}
+
+// loadConversation reads a thread or session file into the provider-neutral shape
+func loadConversation(source, name string) (*Conversation, error) {
+	conv := &Conversation{Title: name, Source: source, Name: name}
+
+	if source == "session" {
+		data, err := os.ReadFile(sessionFile(name))
+		if err != nil {
+			return nil, fmt.Errorf("session not found: %s", name)
+		}
+		var session Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			return nil, fmt.Errorf("failed to parse session %s: %v", name, err)
+		}
+		conv.Messages = session.Messages
+		return conv, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(getHistoryDir(), name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("thread not found: %s", name)
+	}
+	var messages []ChatGPTMessage
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse thread %s: %v", name, err)
+	}
+	for _, m := range messages {
+		if m.Role == "system" {
+			continue
+		}
+		msg := SessionMessage{Role: m.Role, Content: m.Content, Model: m.Model, Timestamp: m.Timestamp, Usage: m.Usage}
+		if m.Role == "assistant" {
+			msg.Provider = "openai"
+		}
+		conv.Messages = append(conv.Messages, msg)
+	}
+	return conv, nil
+}
+
+// TotalUsage sums the reported token usage over all turns
+func (c *Conversation) TotalUsage() TokenUsage {
+	var total TokenUsage
+	for _, m := range c.Messages {
+		if m.Usage != nil {
+			total.InputTokens += m.Usage.InputTokens
+			total.OutputTokens += m.Usage.OutputTokens
+		}
+	}
+	return total
+}
+
+// Subtitle describes the source and size of the conversation
+func (c *Conversation) Subtitle() string {
+	return fmt.Sprintf("%s %s · %d messages · exported %s", c.Source, c.Name, len(c.Messages), time.Now().Format("2006-01-02 15:04"))
+}
+
+// TurnHeading describes the author, model, time and optionally token usage of a turn
+func (m SessionMessage) TurnHeading(withUsage bool) string {
+	parts := []string{"🧑 User"}
+	if m.Role == "assistant" {
+		parts = []string{"🤖 Assistant"}
+		if m.Model != "" {
+			parts = append(parts, m.Model)
+		}
+	}
+	if ts := formatTimestamp(m.Timestamp); ts != "" {
+		parts = append(parts, ts)
+	}
+	if withUsage && m.Usage != nil {
+		parts = append(parts, fmt.Sprintf("%d in / %d out tokens", m.Usage.InputTokens, m.Usage.OutputTokens))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Markdown renders the conversation as a self-contained markdown document
+func (c *Conversation) Markdown(withUsage bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n_%s_\n", c.Title, c.Subtitle())
+
+	for _, m := range c.Messages {
+		fmt.Fprintf(&b, "\n---\n\n### %s\n\n%s\n", m.TurnHeading(withUsage), strings.TrimSpace(m.Content))
+	}
+
+	if withUsage {
+		total := c.TotalUsage()
+		fmt.Fprintf(&b, "\n---\n\n**Total tokens:** %d in / %d out\n", total.InputTokens, total.OutputTokens)
+	}
+	return b.String()
+}
+
+// formatTimestamp renders thread and session timestamps in local time
+func formatTimestamp(ts string) string {
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -13,17 +13,33 @@ import (
 
 // GeminiClient handles Gemini HTTP API integration
 type GeminiClient struct {
-	apiKey string
-	client *http.Client
+	redaction
+	apiKey  string
+	client  *http.Client
+	persona *Persona
+	params  *GenerationParams
+	session *Session
 }
 
 // GeminiRequest represents the Google Gemini API request structure
 type GeminiRequest struct {
-	Contents []GeminiContent `json:"contents"`
+	Contents          []GeminiContent         `json:"contents"`
+	SystemInstruction *GeminiContent          `json:"systemInstruction,omitempty"`
+	GenerationConfig  *GeminiGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+// GeminiGenerationConfig represents generation parameters in the request
+type GeminiGenerationConfig struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"topP,omitempty"`
+	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
+	StopSequences   []string `json:"stopSequences,omitempty"`
+	Seed            *int     `json:"seed,omitempty"`
 }
 
 // GeminiContent represents content in the request
 type GeminiContent struct {
+	Role  string       `json:"role,omitempty"`
 	Parts []GeminiPart `json:"parts"`
 }
 
@@ -34,13 +50,35 @@ type GeminiPart struct {
 
 // GeminiResponse represents the Google Gemini API response structure
 type GeminiResponse struct {
-	Candidates []GeminiCandidate `json:"candidates"`
-	Error      *GeminiError      `json:"error,omitempty"`
+	Candidates     []GeminiCandidate     `json:"candidates"`
+	PromptFeedback *GeminiPromptFeedback `json:"promptFeedback,omitempty"`
+	UsageMetadata  *GeminiUsageMetadata  `json:"usageMetadata,omitempty"`
+	Error          *GeminiError          `json:"error,omitempty"`
+}
+
+// GeminiUsageMetadata reports token counts for a response
+type GeminiUsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+}
+
+// tokenUsage converts the response usage, nil when not reported
+func (u *GeminiUsageMetadata) tokenUsage() *TokenUsage {
+	if u == nil {
+		return nil
+	}
+	return &TokenUsage{InputTokens: u.PromptTokenCount, OutputTokens: u.CandidatesTokenCount}
+}
+
+// GeminiPromptFeedback reports why a prompt was blocked
+type GeminiPromptFeedback struct {
+	BlockReason string `json:"blockReason"`
 }
 
 // GeminiCandidate represents a response candidate
 type GeminiCandidate struct {
-	Content GeminiContent `json:"content"`
+	Content      GeminiContent `json:"content"`
+	FinishReason string        `json:"finishReason"`
 }
 
 // GeminiError represents an API error
@@ -78,35 +116,46 @@ func NewGeminiClientFromStore() (*GeminiClient, error) {
 }
 
 // SendMessage sends a message to Gemini via HTTP API
-func (g *GeminiClient) SendMessage(message string) (string, error) {
+func (g *GeminiClient) SendMessage(ctx context.Context, message string) (string, error) {
+	// Mask secrets before they leave the machine
+	message = g.redact(message)
+
+	// Replay session transcript before the new message (Gemini calls the assistant "model")
+	var contents []GeminiContent
+	if g.session != nil {
+		for _, m := range g.session.Messages {
+			role := m.Role
+			if role == "assistant" {
+				role = "model"
+			}
+			contents = append(contents, GeminiContent{Role: role, Parts: []GeminiPart{{Text: m.Content}}})
+		}
+	}
+	contents = append(contents, GeminiContent{Role: "user", Parts: []GeminiPart{{Text: message}}})
+
 	reqBody := GeminiRequest{
-		Contents: []GeminiContent{
-			{
-				Parts: []GeminiPart{
-					{
-						Text: message,
-					},
-				},
-			},
-		},
+		Contents: contents,
+	}
+	if g.persona != nil && g.persona.SystemPrompt != "" {
+		reqBody.SystemInstruction = &GeminiContent{
+			Parts: []GeminiPart{{Text: g.persona.SystemPrompt}},
+		}
+	}
+	generationConfig, err := g.generationConfig()
+	if err != nil {
+		return "", err
 	}
+	reqBody.GenerationConfig = generationConfig
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	// Load config to get model name
-	cfg, err := config.LoadConfig()
-	model := "gemini-2.5-flash" // Default fallback
-	if err == nil {
-		model = cfg.AI.Models.Google
-	}
-
-	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", model, g.apiKey)
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", g.GetModel(), g.apiKey)
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		"POST",
 		url,
 		bytes.NewBuffer(jsonData),
@@ -119,7 +168,7 @@ func (g *GeminiClient) SendMessage(message string) (string, error) {
 
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %v", err)
+		return "", classifyRequestError("Google", err)
 	}
 	defer resp.Body.Close()
 
@@ -129,7 +178,7 @@ func (g *GeminiClient) SendMessage(message string) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return "", classifyHTTPError("Google", resp, body)
 	}
 
 	var response GeminiResponse
@@ -138,13 +187,22 @@ func (g *GeminiClient) SendMessage(message string) (string, error) {
 	}
 
 	if response.Error != nil {
-		return "", fmt.Errorf("Gemini API error: %s", response.Error.Message)
+		return "", &APIError{Kind: classifyKind(response.Error.Code, response.Error.Status, response.Error.Message), Provider: "Google", StatusCode: response.Error.Code, Code: response.Error.Status, Message: response.Error.Message}
+	}
+
+	if response.PromptFeedback != nil && response.PromptFeedback.BlockReason != "" {
+		return "", &APIError{Kind: ErrContentFilter, Provider: "Google", Code: response.PromptFeedback.BlockReason, Message: "prompt was blocked"}
 	}
 
 	if len(response.Candidates) == 0 {
 		return "", fmt.Errorf("no response candidates received")
 	}
 
+	switch reason := response.Candidates[0].FinishReason; reason {
+	case "SAFETY", "PROHIBITED_CONTENT", "BLOCKLIST", "SPII", "RECITATION":
+		return "", &APIError{Kind: ErrContentFilter, Provider: "Google", Code: reason, Message: "response was blocked"}
+	}
+
 	if len(response.Candidates[0].Content.Parts) == 0 {
 		return "", fmt.Errorf("no content parts in response")
 	}
@@ -154,11 +212,56 @@ func (g *GeminiClient) SendMessage(message string) (string, error) {
 		return "", fmt.Errorf("empty response content")
 	}
 
+	if g.session != nil {
+		if err := g.session.Record("google", g.GetModel(), message, content, response.UsageMetadata.tokenUsage()); err != nil {
+			LogError("Failed to save session: %v", err)
+		}
+	}
+
 	return content, nil
 }
 
+// SetPersona applies a persona's system prompt, model and temperature
+func (g *GeminiClient) SetPersona(persona *Persona) {
+	g.persona = persona
+}
+
+// SetSession continues a named provider-neutral session
+func (g *GeminiClient) SetSession(session *Session) {
+	g.session = session
+}
+
+// SetParams sets the generation parameters for subsequent requests
+func (g *GeminiClient) SetParams(params *GenerationParams) {
+	g.params = params
+}
+
+// generationConfig maps generation parameters to the Gemini request schema
+func (g *GeminiClient) generationConfig() (*GeminiGenerationConfig, error) {
+	p := g.params
+	if p == nil || (p.Temperature == nil && p.TopP == nil && p.MaxTokens == 0 && len(p.Stop) == 0 && p.Seed == nil) {
+		return nil, nil
+	}
+
+	if len(p.Stop) > 5 {
+		return nil, fmt.Errorf("Gemini accepts at most 5 stop sequences, got %d", len(p.Stop))
+	}
+
+	return &GeminiGenerationConfig{
+		Temperature:     p.Temperature,
+		TopP:            p.TopP,
+		MaxOutputTokens: p.MaxTokens,
+		StopSequences:   p.Stop,
+		Seed:            p.Seed,
+	}, nil
+}
+
 // GetModel returns the model name
 func (g *GeminiClient) GetModel() string {
+	if g.persona != nil && g.persona.Model != "" {
+		return g.persona.Model
+	}
+
 	// Load config to get model name
 	cfg, err := config.LoadConfig()
 	if err == nil {
@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,7 +13,11 @@ import (
 
 // GrokClient handles xAI Grok CLI integration
 type GrokClient struct {
+	redaction
 	workDir string // .grok directory path
+	persona *Persona
+	params  *GenerationParams
+	session *Session
 }
 
 // NewGrokClient creates a new Grok client
@@ -26,7 +31,7 @@ func (g *GrokClient) SetupWorkDir() error {
 		return fmt.Errorf("failed to create .grok directory: %v", err)
 	}
 
-	settings := map[string]string{"model": "grok-code-fast-1"}
+	settings := map[string]string{"model": g.GetModel()}
 	data, err := json.Marshal(settings)
 	if err != nil {
 		return fmt.Errorf("failed to marshal settings: %v", err)
@@ -41,50 +46,72 @@ func (g *GrokClient) SetupWorkDir() error {
 }
 
 // SendMessage wraps grok CLI
-func (g *GrokClient) SendMessage(message string) (string, error) {
-	// Setup work directory
-	if err := g.SetupWorkDir(); err != nil {
+func (g *GrokClient) SendMessage(ctx context.Context, message string) (string, error) {
+	if err := g.checkParams(); err != nil {
 		return "", err
 	}
 
-	// Run grok CLI
-	cmd := exec.Command("grok", "-p", message)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("grok CLI failed: %v", err)
+	// Setup work directory
+	if err := g.SetupWorkDir(); err != nil {
+		return "", err
 	}
 
-	// Extract JSON content using Python
-	content, err := g.extractContent(string(output))
-	if err != nil {
-		return "", err
+	// Persona system prompt goes to GROK.md like prompt files
+	if g.persona != nil && g.persona.SystemPrompt != "" {
+		grokMdPath := filepath.Join(g.workDir, "GROK.md")
+		if err := os.WriteFile(grokMdPath, []byte(g.persona.SystemPrompt), 0644); err != nil {
+			return "", fmt.Errorf("failed to write GROK.md: %v", err)
+		}
 	}
 
-	return content, nil
+	return g.run(ctx, message)
 }
 
 // SendMessageWithPrompt copies prompt to GROK.md and sends message
-func (g *GrokClient) SendMessageWithPrompt(promptFile, message string) (string, error) {
+func (g *GrokClient) SendMessageWithPrompt(ctx context.Context, promptFile, message string) (string, error) {
+	if err := g.checkParams(); err != nil {
+		return "", err
+	}
+
 	if err := g.SetupWorkDir(); err != nil {
 		return "", err
 	}
 
 	// Copy prompt file to .grok/GROK.md (grok CLI expects prompt here)
 	grokMdPath := filepath.Join(g.workDir, "GROK.md")
-	promptData, err := os.ReadFile(promptFile)
+	promptData, err := ReadPromptBody(promptFile)
 	if err != nil {
-		return "", fmt.Errorf("failed to read prompt file: %v", err)
+		return "", err
 	}
 
-	if err := os.WriteFile(grokMdPath, promptData, 0644); err != nil {
+	if err := os.WriteFile(grokMdPath, []byte(promptData), 0644); err != nil {
 		return "", fmt.Errorf("failed to write GROK.md: %v", err)
 	}
 
 	// Run grok CLI with user message (prompt is already in GROK.md)
-	cmd := exec.Command("grok", "-p", message)
+	return g.run(ctx, message)
+}
+
+// run calls the grok CLI, replaying the session transcript inline since the CLI keeps no history
+func (g *GrokClient) run(ctx context.Context, message string) (string, error) {
+	// Mask secrets before they leave the machine
+	message = g.redact(message)
+
+	prompt := message
+	if g.session != nil && len(g.session.Messages) > 0 {
+		prompt = "Previous conversation:\n\n" + g.session.Transcript() + "User: " + message
+	}
+
+	cmd := exec.CommandContext(ctx, "grok", "-p", prompt)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("grok CLI failed: %v", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("grok CLI cancelled: %w", ctxErr)
+		}
+		if len(output) == 0 {
+			return "", fmt.Errorf("grok CLI failed: %v", err)
+		}
+		return "", classifyCLIOutput("xAI", string(output))
 	}
 
 	content, err := g.extractContent(string(output))
@@ -92,6 +119,12 @@ func (g *GrokClient) SendMessageWithPrompt(promptFile, message string) (string,
 		return "", err
 	}
 
+	if g.session != nil {
+		if err := g.session.Record("xai", g.GetModel(), message, content, nil); err != nil {
+			LogError("Failed to save session: %v", err)
+		}
+	}
+
 	return content, nil
 }
 
@@ -175,7 +208,37 @@ func (g *GrokClient) Cleanup() error {
 	return os.RemoveAll(g.workDir)
 }
 
+// SetPersona applies a persona's system prompt and model
+func (g *GrokClient) SetPersona(persona *Persona) {
+	g.persona = persona
+}
+
+// SetSession continues a named provider-neutral session
+func (g *GrokClient) SetSession(session *Session) {
+	g.session = session
+}
+
+// SetParams sets the generation parameters for subsequent requests
+func (g *GrokClient) SetParams(params *GenerationParams) {
+	g.params = params
+}
+
+// checkParams rejects generation parameters, which the grok CLI does not expose
+func (g *GrokClient) checkParams() error {
+	for _, param := range []string{"temperature", "max-tokens", "top-p", "stop", "seed"} {
+		if g.params.isSet(param) {
+			if err := g.params.unsupported("Grok", g.GetModel(), param, "grok CLI does not expose sampling parameters"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // GetModel returns the model name
 func (g *GrokClient) GetModel() string {
+	if g.persona != nil && g.persona.Model != "" {
+		return g.persona.Model
+	}
 	return "grok-code-fast-1"
 }
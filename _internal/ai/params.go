@@ -0,0 +1,163 @@
+package ai
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"cli-go/_internal/config"
+)
+
+// GenerationParams holds provider-neutral sampling parameters
+type GenerationParams struct {
+	Temperature *float64
+	MaxTokens   int
+	TopP        *float64
+	Stop        []string
+	Seed        *int
+
+	explicit map[string]bool // parameters set via command line flags
+}
+
+// GenerationFlags holds the common generation flags registered by AI tools
+type GenerationFlags struct {
+	temperature float64
+	maxTokens   int
+	topP        float64
+	stop        string
+	seed        int
+}
+
+// UnsupportedParamError is returned when a provider or model rejects a parameter
+type UnsupportedParamError struct {
+	Provider string
+	Model    string
+	Param    string
+	Reason   string
+}
+
+func (e *UnsupportedParamError) Error() string {
+	msg := fmt.Sprintf("%s model %s does not support --%s", e.Provider, e.Model, e.Param)
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
+
+// RegisterGenerationFlags registers --temperature, --max-tokens, --top-p, --stop and --seed
+func RegisterGenerationFlags() *GenerationFlags {
+	f := &GenerationFlags{}
+	flag.Float64Var(&f.temperature, "temperature", 0, "Sampling temperature")
+	flag.IntVar(&f.maxTokens, "max-tokens", 0, "Maximum tokens in the response")
+	flag.Float64Var(&f.topP, "top-p", 0, "Nucleus sampling probability (0-1)")
+	flag.StringVar(&f.stop, "stop", "", "Comma-separated stop sequences")
+	flag.IntVar(&f.seed, "seed", 0, "Seed for reproducible sampling")
+	return f
+}
+
+// Resolve merges config defaults, the tool's config, the persona and flags (in that order)
+func (f *GenerationFlags) Resolve(tool string, persona *Persona) (*GenerationParams, error) {
+	params := &GenerationParams{explicit: map[string]bool{}}
+
+	if cfg, err := config.LoadConfig(); err == nil {
+		params.merge(cfg.AI.Generation["default"])
+		params.merge(cfg.AI.Generation[tool])
+	}
+
+	if persona != nil && persona.Temperature != nil {
+		params.Temperature = persona.Temperature
+	}
+
+	flag.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "temperature":
+			params.Temperature = &f.temperature
+		case "max-tokens":
+			params.MaxTokens = f.maxTokens
+		case "top-p":
+			params.TopP = &f.topP
+		case "stop":
+			params.Stop = splitStop(f.stop)
+		case "seed":
+			params.Seed = &f.seed
+		default:
+			return
+		}
+		params.explicit[fl.Name] = true
+	})
+
+	return params, params.validate()
+}
+
+// merge applies non-empty values from a config entry
+func (p *GenerationParams) merge(gc config.GenerationConfig) {
+	if gc.Temperature != nil {
+		p.Temperature = gc.Temperature
+	}
+	if gc.MaxTokens != 0 {
+		p.MaxTokens = gc.MaxTokens
+	}
+	if gc.TopP != nil {
+		p.TopP = gc.TopP
+	}
+	if len(gc.Stop) > 0 {
+		p.Stop = gc.Stop
+	}
+	if gc.Seed != nil {
+		p.Seed = gc.Seed
+	}
+}
+
+// validate checks provider-independent ranges
+func (p *GenerationParams) validate() error {
+	if p.Temperature != nil && (*p.Temperature < 0 || *p.Temperature > 2) {
+		return fmt.Errorf("temperature must be between 0 and 2, got %g", *p.Temperature)
+	}
+	if p.TopP != nil && (*p.TopP <= 0 || *p.TopP > 1) {
+		return fmt.Errorf("top-p must be in (0, 1], got %g", *p.TopP)
+	}
+	if p.MaxTokens < 0 {
+		return fmt.Errorf("max-tokens must be positive, got %d", p.MaxTokens)
+	}
+	return nil
+}
+
+// unsupported returns an error for explicitly requested parameters and drops defaults with a warning
+func (p *GenerationParams) unsupported(provider, model, param, reason string) error {
+	if p.explicit[param] {
+		return &UnsupportedParamError{Provider: provider, Model: model, Param: param, Reason: reason}
+	}
+	LogInfo("⚠️  %s model %s ignores configured %s", provider, model, param)
+	return nil
+}
+
+// isSet reports whether a parameter has a value
+func (p *GenerationParams) isSet(param string) bool {
+	if p == nil {
+		return false
+	}
+	switch param {
+	case "temperature":
+		return p.Temperature != nil
+	case "max-tokens":
+		return p.MaxTokens != 0
+	case "top-p":
+		return p.TopP != nil
+	case "stop":
+		return len(p.Stop) > 0
+	case "seed":
+		return p.Seed != nil
+	}
+	return false
+}
+
+// splitStop splits a comma-separated list of stop sequences
+func splitStop(value string) []string {
+	var stops []string
+	for _, s := range strings.Split(value, ",") {
+		if s != "" {
+			stops = append(stops, s)
+		}
+	}
+	return stops
+}
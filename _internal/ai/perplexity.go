@@ -12,8 +12,11 @@ import (
 
 // PerplexityClient handles Perplexity API interactions
 type PerplexityClient struct {
-	apiKey string
-	client *http.Client
+	redaction
+	apiKey  string
+	client  *http.Client
+	persona *Persona
+	params  *GenerationParams
 }
 
 // PerplexityRequest represents the API request structure
@@ -22,6 +25,7 @@ type PerplexityRequest struct {
 	Messages    []Message `json:"messages"`
 	MaxTokens   int       `json:"max_tokens"`
 	Temperature float64   `json:"temperature"`
+	TopP        *float64  `json:"top_p,omitempty"`
 }
 
 // Message represents a chat message
@@ -58,7 +62,10 @@ func NewPerplexityClient(apiKey string) *PerplexityClient {
 }
 
 // Search performs a web search using Perplexity API
-func (c *PerplexityClient) Search(query string) (string, error) {
+func (c *PerplexityClient) Search(ctx context.Context, query string) (string, error) {
+	// Mask secrets before they leave the machine
+	query = c.redact(query)
+
 	reqBody := PerplexityRequest{
 		Model: "sonar-pro",
 		Messages: []Message{
@@ -70,6 +77,17 @@ func (c *PerplexityClient) Search(query string) (string, error) {
 		MaxTokens:   1000,
 		Temperature: 0.2,
 	}
+	if c.persona != nil {
+		if c.persona.SystemPrompt != "" {
+			reqBody.Messages = append([]Message{{Role: "system", Content: c.persona.SystemPrompt}}, reqBody.Messages...)
+		}
+		if c.persona.Model != "" {
+			reqBody.Model = c.persona.Model
+		}
+	}
+	if err := c.applyParams(&reqBody); err != nil {
+		return "", err
+	}
 
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -77,7 +95,7 @@ func (c *PerplexityClient) Search(query string) (string, error) {
 	}
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		"POST",
 		"https://api.perplexity.ai/chat/completions",
 		bytes.NewBuffer(jsonData),
@@ -91,7 +109,7 @@ func (c *PerplexityClient) Search(query string) (string, error) {
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("API request failed: %v", err)
+		return "", classifyRequestError("Perplexity", err)
 	}
 	defer resp.Body.Close()
 
@@ -101,7 +119,7 @@ func (c *PerplexityClient) Search(query string) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", c.handleAPIError(resp.StatusCode, body, query)
+		return "", c.handleAPIError(classifyHTTPError("Perplexity", resp, body), query)
 	}
 
 	var response PerplexityResponse
@@ -110,7 +128,7 @@ func (c *PerplexityClient) Search(query string) (string, error) {
 	}
 
 	if response.Error != nil {
-		return "", fmt.Errorf("API error: %s", response.Error.Message)
+		return "", &APIError{Kind: classifyKind(0, response.Error.Type, response.Error.Message), Provider: "Perplexity", Code: response.Error.Type, Message: response.Error.Message}
 	}
 
 	if len(response.Choices) == 0 {
@@ -125,11 +143,48 @@ func (c *PerplexityClient) Search(query string) (string, error) {
 	return content, nil
 }
 
+// SetPersona applies a persona's system prompt, model and temperature
+func (c *PerplexityClient) SetPersona(persona *Persona) {
+	c.persona = persona
+}
+
+// SetParams sets the generation parameters for subsequent requests
+func (c *PerplexityClient) SetParams(params *GenerationParams) {
+	c.params = params
+}
+
+// applyParams maps generation parameters to the Perplexity request schema
+func (c *PerplexityClient) applyParams(req *PerplexityRequest) error {
+	p := c.params
+	if p == nil {
+		return nil
+	}
+
+	for _, param := range []string{"stop", "seed"} {
+		if p.isSet(param) {
+			if err := p.unsupported("Perplexity", req.Model, param, ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	if p.Temperature != nil {
+		req.Temperature = *p.Temperature
+	}
+	if p.MaxTokens > 0 {
+		req.MaxTokens = p.MaxTokens
+	}
+	req.TopP = p.TopP
+	return nil
+}
+
 // handleAPIError handles different API error scenarios with mock responses
-func (c *PerplexityClient) handleAPIError(statusCode int, body []byte, query string) error {
+func (c *PerplexityClient) handleAPIError(apiErr *APIError, query string) error {
+	statusCode := apiErr.StatusCode
 	switch statusCode {
 	case http.StatusUnauthorized:
 		return &MockAPIError{
+			Cause:      apiErr,
 			StatusCode: statusCode,
 			Query:      query,
 			Message:    "API key is invalid or expired",
@@ -143,6 +198,7 @@ The system is working correctly - it just needs a valid API key to make real API
 		}
 	case http.StatusTooManyRequests:
 		return &MockAPIError{
+			Cause:      apiErr,
 			StatusCode: statusCode,
 			Query:      query,
 			Message:    "rate limit exceeded",
@@ -154,13 +210,10 @@ Please wait before making another request.
 The system is working correctly - it just needs to wait before making more requests.`, query),
 		}
 	case http.StatusBadRequest:
-		var response PerplexityResponse
-		if err := json.Unmarshal(body, &response); err == nil && response.Error != nil {
-			return fmt.Errorf("bad request: %s", response.Error.Message)
-		}
-		return fmt.Errorf("bad request - check your query format")
+		return apiErr
 	case http.StatusInternalServerError:
 		return &MockAPIError{
+			Cause:      apiErr,
 			StatusCode: statusCode,
 			Query:      query,
 			Message:    "server error",
@@ -173,6 +226,7 @@ The system is working correctly - it just needs the API server to be available.`
 		}
 	default:
 		return &MockAPIError{
+			Cause:      apiErr,
 			StatusCode: statusCode,
 			Query:      query,
 			Message:    fmt.Sprintf("HTTP error %d", statusCode),
@@ -188,6 +242,7 @@ The system is working correctly - it just needs a successful API response.`, que
 
 // MockAPIError represents an API error that should return a mock response
 type MockAPIError struct {
+	Cause        *APIError // classified error behind the mock response
 	StatusCode   int
 	Query        string
 	Message      string
@@ -198,6 +253,11 @@ func (e *MockAPIError) Error() string {
 	return e.Message
 }
 
+// Unwrap exposes the classified error to errors.As
+func (e *MockAPIError) Unwrap() error {
+	return e.Cause
+}
+
 // GetMockResponse returns the mock response content
 func (e *MockAPIError) GetMockResponse() string {
 	return e.MockResponse
@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cli-go/_internal/config"
+)
+
+// DefaultSystemPrompt is used when no persona is configured
+const DefaultSystemPrompt = "You are a helpful assistant."
+
+// Persona holds a resolved system prompt and generation defaults
+type Persona struct {
+	Name         string
+	SystemPrompt string
+	Model        string
+	Temperature  *float64
+}
+
+// ResolvePersona resolves a persona by name, falling back to the tool's default persona.
+// Returns nil when neither a name nor a tool default is configured.
+func ResolvePersona(tool, name string) (*Persona, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		if name != "" {
+			return nil, fmt.Errorf("failed to load config: %v", err)
+		}
+		return nil, nil
+	}
+
+	if name == "" {
+		name = cfg.AI.ToolPersonas[tool]
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	personaConfig, ok := cfg.AI.Personas[name]
+	if !ok {
+		return nil, fmt.Errorf("persona not found: %s (available: %s)", name, strings.Join(personaNames(cfg), ", "))
+	}
+
+	systemPrompt := personaConfig.SystemPrompt
+	if personaConfig.PromptFile != "" {
+		content, err := os.ReadFile(resolvePromptPath(cfg.Prompts.BaseDir, personaConfig.PromptFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt file for persona %s: %v", name, err)
+		}
+		systemPrompt = strings.TrimSpace(string(content))
+	}
+
+	return &Persona{
+		Name:         name,
+		SystemPrompt: systemPrompt,
+		Model:        personaConfig.Model,
+		Temperature:  personaConfig.Temperature,
+	}, nil
+}
+
+// personaNames returns the configured persona names in sorted order
+func personaNames(cfg *config.Config) []string {
+	var names []string
+	for name := range cfg.AI.Personas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolvePromptPath expands ~ and resolves relative paths against the prompts directory
+func resolvePromptPath(baseDir, path string) string {
+	path = expandHome(path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	if !strings.HasSuffix(path, ".md") {
+		path += ".md"
+	}
+	return filepath.Join(expandHome(baseDir), path)
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err == nil {
+			return strings.Replace(path, "~", homeDir, 1)
+		}
+	}
+	return path
+}
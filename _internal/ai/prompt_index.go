@@ -0,0 +1,239 @@
+package ai
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PromptEntry describes one prompt file in the prompts directory
+type PromptEntry struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Path        string   `json:"path"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Score       int      `json:"score,omitempty"`
+
+	content string
+}
+
+// promptFrontMatter holds the optional YAML header of a prompt file
+type promptFrontMatter struct {
+	Title       string      `yaml:"title"`
+	Description string      `yaml:"description"`
+	Tags        interface{} `yaml:"tags"` // list or comma-separated string
+}
+
+// Index lists all prompts sorted by name
+func (p *PromptClient) Index() ([]PromptEntry, error) {
+	files, err := p.findPromptFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find prompt files: %v", err)
+	}
+
+	var entries []PromptEntry
+	for _, file := range files {
+		entry, err := p.loadEntry(file)
+		if err != nil {
+			LogError("Skipping prompt %s: %v", file, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// Search returns prompts matching every query term and all given tags, best match first
+func (p *PromptClient) Search(query string, tags []string) ([]PromptEntry, error) {
+	entries, err := p.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	var results []PromptEntry
+	for _, entry := range entries {
+		if !hasAllTags(entry, tags) {
+			continue
+		}
+		score, ok := scoreEntry(entry, terms)
+		if !ok {
+			continue
+		}
+		entry.Score = score
+		results = append(results, entry)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results, nil
+}
+
+// Resolve finds a prompt file by path, ID, name or unique base name
+func (p *PromptClient) Resolve(nameOrID string) (string, error) {
+	if info, err := os.Stat(nameOrID); err == nil && !info.IsDir() {
+		return nameOrID, nil
+	}
+
+	entries, err := p.Index()
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSuffix(nameOrID, ".md")
+	var baseMatches []PromptEntry
+	for _, entry := range entries {
+		if entry.ID == nameOrID || entry.Name == name {
+			return entry.Path, nil
+		}
+		if filepath.Base(entry.Name) == name {
+			baseMatches = append(baseMatches, entry)
+		}
+	}
+
+	switch len(baseMatches) {
+	case 0:
+		return "", fmt.Errorf("prompt not found: %s (run 'prompts list')", nameOrID)
+	case 1:
+		return baseMatches[0].Path, nil
+	default:
+		var names []string
+		for _, entry := range baseMatches {
+			names = append(names, fmt.Sprintf("%s (%s)", entry.Name, entry.ID))
+		}
+		return "", fmt.Errorf("prompt %s is ambiguous: %s", nameOrID, strings.Join(names, ", "))
+	}
+}
+
+// PromptName returns the index name of a prompt file, or its base name outside the prompts directory
+func (p *PromptClient) PromptName(promptFile string) string {
+	if rel, err := filepath.Rel(p.baseDir, promptFile); err == nil && !strings.HasPrefix(rel, "..") {
+		return strings.TrimSuffix(filepath.ToSlash(rel), ".md")
+	}
+	return strings.TrimSuffix(filepath.Base(promptFile), ".md")
+}
+
+// loadEntry reads a prompt file and its front matter
+func (p *PromptClient) loadEntry(file string) (PromptEntry, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return PromptEntry{}, err
+	}
+
+	name := p.PromptName(file)
+	hash := sha1.Sum([]byte(name))
+	entry := PromptEntry{
+		ID:      hex.EncodeToString(hash[:])[:8],
+		Name:    name,
+		Path:    file,
+		content: string(data),
+	}
+
+	header, body, ok := splitFrontMatter(string(data))
+	if !ok {
+		return entry, nil
+	}
+
+	var fm promptFrontMatter
+	if err := yaml.Unmarshal([]byte(header), &fm); err != nil {
+		return entry, fmt.Errorf("invalid front matter: %v", err)
+	}
+	entry.Title = fm.Title
+	entry.Description = fm.Description
+	entry.Tags = parseTags(fm.Tags)
+	entry.content = body
+	return entry, nil
+}
+
+// ReadPromptBody reads a prompt file without its front matter, which is index metadata and not part of the prompt
+func ReadPromptBody(promptFile string) (string, error) {
+	data, err := os.ReadFile(promptFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt file: %v", err)
+	}
+	_, body, _ := splitFrontMatter(string(data))
+	return body, nil
+}
+
+// splitFrontMatter separates a leading "---" YAML block from the body
+func splitFrontMatter(content string) (string, string, bool) {
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content, false
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return "", content, false
+	}
+	header := content[4 : 4+end]
+	body := strings.TrimPrefix(content[4+end+4:], "\n")
+	return header, body, true
+}
+
+// parseTags accepts a YAML list or a comma-separated string
+func parseTags(raw interface{}) []string {
+	var tags []string
+	switch v := raw.(type) {
+	case string:
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, strings.ToLower(tag))
+			}
+		}
+	case []interface{}:
+		for _, tag := range v {
+			tags = append(tags, strings.ToLower(fmt.Sprint(tag)))
+		}
+	}
+	return tags
+}
+
+// hasAllTags reports whether the entry carries every requested tag
+func hasAllTags(entry PromptEntry, tags []string) bool {
+	for _, want := range tags {
+		found := false
+		for _, tag := range entry.Tags {
+			if tag == strings.ToLower(want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// scoreEntry ranks name and title hits above tags, description and body text
+func scoreEntry(entry PromptEntry, terms []string) (int, bool) {
+	name := strings.ToLower(entry.Name)
+	title := strings.ToLower(entry.Title)
+	meta := strings.ToLower(entry.Description + " " + strings.Join(entry.Tags, " "))
+	body := strings.ToLower(entry.content)
+
+	score := 0
+	for _, term := range terms {
+		switch {
+		case strings.Contains(name, term):
+			score += 4
+		case strings.Contains(title, term):
+			score += 3
+		case strings.Contains(meta, term):
+			score += 2
+		case strings.Contains(body, term):
+			score += 1
+		default:
+			return 0, false
+		}
+	}
+	return score, true
+}
@@ -46,38 +46,34 @@ func NewPromptClient() *PromptClient {
 
 // SelectPrompt interactively selects a prompt using fzf
 func (p *PromptClient) SelectPrompt() (string, error) {
-	// Find all .md files in the prompts directory
-	files, err := p.findPromptFiles()
+	entries, err := p.Index()
 	if err != nil {
-		return "", fmt.Errorf("failed to find prompt files: %v", err)
+		return "", err
 	}
 
-	if len(files) == 0 {
+	if len(entries) == 0 {
 		return "", fmt.Errorf("no prompt files found")
 	}
 
-	// Format files for fzf display
-	formattedFiles := p.formatFilesForFzf(files)
-
 	// Use fzf to select a file
-	selected, err := p.runFzf(formattedFiles)
+	selected, err := p.runFzf(p.formatEntriesForFzf(entries))
 	if err != nil {
 		return "", fmt.Errorf("fzf selection failed: %v", err)
 	}
 
-	// Convert back to actual file path
-	actualFile := p.convertFzfToActualPath(selected)
-	return actualFile, nil
+	// The hidden first column carries the prompt ID, so display names never need reversing
+	id := strings.SplitN(selected, "\t", 2)[0]
+	return p.Resolve(id)
 }
 
-// LoadPrompt loads the content of a prompt file
-func (p *PromptClient) LoadPrompt(promptFile string) (string, error) {
-	content, err := os.ReadFile(promptFile)
-	if err != nil {
-		return "", fmt.Errorf("failed to read prompt file: %v", err)
-	}
+// BaseDir returns the prompts directory
+func (p *PromptClient) BaseDir() string {
+	return p.baseDir
+}
 
-	return string(content), nil
+// LoadPrompt loads the content of a prompt file without its front matter
+func (p *PromptClient) LoadPrompt(promptFile string) (string, error) {
+	return ReadPromptBody(promptFile)
 }
 
 // findPromptFiles finds all .md files in the prompts directory
@@ -99,24 +95,20 @@ func (p *PromptClient) findPromptFiles() ([]string, error) {
 	return files, err
 }
 
-// formatFilesForFzf formats file paths for fzf display
-func (p *PromptClient) formatFilesForFzf(files []string) []string {
+// formatEntriesForFzf formats prompts as "id<TAB>display name" lines for fzf
+func (p *PromptClient) formatEntriesForFzf(entries []PromptEntry) []string {
 	var formatted []string
 
-	for _, file := range files {
-		// Remove base directory
-		relative := strings.TrimPrefix(file, p.baseDir+"/")
+	for _, entry := range entries {
+		// Replace - with spaces and / with →
+		display := strings.ReplaceAll(entry.Name, "-", " ")
+		display = strings.ReplaceAll(display, "/", " → ")
 
-		// Remove .md extension
-		relative = strings.TrimSuffix(relative, ".md")
-
-		// Replace - with spaces
-		relative = strings.ReplaceAll(relative, "-", " ")
-
-		// Replace / with →
-		relative = strings.ReplaceAll(relative, "/", " → ")
+		if len(entry.Tags) > 0 {
+			display += "  [" + strings.Join(entry.Tags, ", ") + "]"
+		}
 
-		formatted = append(formatted, relative)
+		formatted = append(formatted, entry.ID+"\t"+display)
 	}
 
 	return formatted
@@ -124,7 +116,7 @@ func (p *PromptClient) formatFilesForFzf(files []string) []string {
 
 // runFzf runs fzf with the given options
 func (p *PromptClient) runFzf(options []string) (string, error) {
-	cmd := exec.Command("fzf", "--prompt", "Select prompt → ")
+	cmd := exec.Command("fzf", "--prompt", "Select prompt → ", "--delimiter", "\t", "--with-nth", "2")
 	cmd.Stdin = strings.NewReader(strings.Join(options, "\n"))
 
 	output, err := cmd.Output()
@@ -146,13 +138,3 @@ func isInteractive() bool {
 	// Check if stdin is a character device (TTY)
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
-
-// convertFzfToActualPath converts fzf selection back to actual file path
-func (p *PromptClient) convertFzfToActualPath(fzfSelection string) string {
-	// Reverse the formatting
-	actual := strings.ReplaceAll(fzfSelection, " → ", "/")
-	actual = strings.ReplaceAll(actual, " ", "-")
-	actual = actual + ".md"
-
-	return filepath.Join(p.baseDir, actual)
-}
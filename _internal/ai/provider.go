@@ -0,0 +1,51 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"cli-go/_internal/config"
+)
+
+// ProviderClient is implemented by every chat client
+type ProviderClient interface {
+	AIClient
+	SetPersona(persona *Persona)
+	SetParams(params *GenerationParams)
+	SetSession(session *Session)
+	SetRedact(enabled bool)
+}
+
+// NewProviderClient creates a stateless client for a provider name or alias
+// (openai/chatgpt/j, anthropic/claude/cld, haiku, google/gemini/gem, xai/grok/gro).
+// ChatGPT clients are ephemeral: they do not read or extend the shell thread.
+func NewProviderClient(provider string) (ProviderClient, error) {
+	switch strings.ToLower(provider) {
+	case "openai", "chatgpt", "j", "":
+		apiKey, err := config.GetKey("openai")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get OpenAI API key: %v", err)
+		}
+		client := NewChatGPTClient("text", apiKey)
+		client.SetEphemeral(true)
+		return client, nil
+	case "anthropic", "claude", "cld", "sonnet":
+		apiKey, err := config.GetKey("anthropic")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Anthropic API key: %v", err)
+		}
+		return NewClaudeClient(ClaudeSonnet, apiKey), nil
+	case "haiku", "haik":
+		apiKey, err := config.GetKey("anthropic")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Anthropic API key: %v", err)
+		}
+		return NewClaudeClient(ClaudeHaiku, apiKey), nil
+	case "google", "gemini", "gem":
+		return NewGeminiClientFromStore()
+	case "xai", "grok", "gro":
+		return NewGrokClient(), nil
+	default:
+		return nil, fmt.Errorf("unknown provider: %s (use openai, anthropic, haiku, google or xai)", provider)
+	}
+}
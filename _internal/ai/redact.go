@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cli-go/_internal/config"
+)
+
+// redactRule masks one secret format; only the submatch named "secret" is replaced when present
+type redactRule struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// redaction is embedded by clients to mask secrets before content leaves the machine
+type redaction struct {
+	disabled bool
+}
+
+// SetRedact enables or disables secret redaction (enabled by default)
+func (r *redaction) SetRedact(enabled bool) {
+	r.disabled = !enabled
+}
+
+// redact masks secrets in text and reports what was masked on stderr
+func (r *redaction) redact(text string) string {
+	if r.disabled {
+		return text
+	}
+	masked, counts := RedactSecrets(text)
+	if summary := FormatRedactions(counts); summary != "" {
+		LogInfo("%s", summary)
+	}
+	return masked
+}
+
+// RedactSecrets masks known secret formats and credential store values.
+// Returns the masked text and the number of matches per secret kind.
+func RedactSecrets(text string) (string, map[string]int) {
+	counts := map[string]int{}
+
+	// Exact values from our own credential store come first so they get the most specific label
+	for service, value := range credentialValues() {
+		if n := strings.Count(text, value); n > 0 {
+			text = strings.ReplaceAll(text, value, mask(service+"-credential"))
+			counts[service+"-credential"] += n
+		}
+	}
+
+	for _, rule := range redactRules() {
+		secretIndex := rule.pattern.SubexpIndex("secret")
+		text = rule.pattern.ReplaceAllStringFunc(text, func(match string) string {
+			counts[rule.kind]++
+			if secretIndex < 0 {
+				return mask(rule.kind)
+			}
+			sub := rule.pattern.FindStringSubmatchIndex(match)
+			return match[:sub[2*secretIndex]] + mask(rule.kind) + match[sub[2*secretIndex+1]:]
+		})
+	}
+
+	return text, counts
+}
+
+// FormatRedactions summarizes redaction counts for stderr, empty when nothing was masked
+func FormatRedactions(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+
+	var kinds []string
+	total := 0
+	for kind, n := range counts {
+		kinds = append(kinds, fmt.Sprintf("%d× %s", n, kind))
+		total += n
+	}
+	sort.Strings(kinds)
+
+	return fmt.Sprintf("🔒 Redacted %d secret(s) before sending: %s (use --no-redact to disable)", total, strings.Join(kinds, ", "))
+}
+
+// mask returns the placeholder for a secret kind
+func mask(kind string) string {
+	return "[REDACTED:" + kind + "]"
+}
+
+// redactRules returns the secret formats, most specific first
+func redactRules() []redactRule {
+	return []redactRule{
+		{"private-key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+		{"jwt", regexp.MustCompile(`eyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+		{"anthropic-key", regexp.MustCompile(`sk-ant-[A-Za-z0-9_-]{20,}`)},
+		{"openai-key", regexp.MustCompile(`sk-(?:proj-|svcacct-)?[A-Za-z0-9_-]{20,}`)},
+		{"google-key", regexp.MustCompile(`AIza[0-9A-Za-z_-]{35}`)},
+		{"github-token", regexp.MustCompile(`(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+		{"aws-access-key", regexp.MustCompile(`(?:AKIA|ASIA)[0-9A-Z]{16}`)},
+		{"slack-token", regexp.MustCompile(`xox[abprs]-[A-Za-z0-9-]{10,}`)},
+		{"groq-key", regexp.MustCompile(`gsk_[A-Za-z0-9]{20,}`)},
+		{"perplexity-key", regexp.MustCompile(`pplx-[A-Za-z0-9]{20,}`)},
+		{"figma-token", regexp.MustCompile(`figd_[A-Za-z0-9_-]{20,}`)},
+		{"atlassian-token", regexp.MustCompile(`ATATT[A-Za-z0-9_=-]{20,}`)},
+		{"url-password", regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s:/@]+:(?P<secret>[^\s@/]+)@`)},
+		{"bearer-token", regexp.MustCompile(`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/-]{20,}=*)`)},
+		{"password", regexp.MustCompile(`(?i)\b(?:password|passwd|pwd|secret|api[_-]?key|access[_-]?token)\b["']?\s*[:=]\s*["']?(?P<secret>[^\s"'\[]{8,})`)},
+	}
+}
+
+// credentialValues returns configured credential values keyed by service (READ-ONLY)
+func credentialValues() map[string]string {
+	values := map[string]string{}
+
+	store, err := config.NewEncryptedStore()
+	if err != nil || !store.Exists() {
+		return values
+	}
+	creds, err := store.LoadCredentials()
+	if err != nil {
+		return values
+	}
+
+	for service, value := range map[string]string{
+		"openai":     creds.OpenAI,
+		"anthropic":  creds.Anthropic,
+		"google":     creds.Google,
+		"groq":       creds.Groq,
+		"perplexity": creds.Perplexity,
+		"figma":      creds.Figma,
+		"jira":       creds.Jira,
+	} {
+		if len(value) >= 8 {
+			values[service] = value
+		}
+	}
+	return values
+}
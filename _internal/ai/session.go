@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"cli-go/_internal/config"
+	"cli-go/_internal/sys"
+)
+
+// SessionEnvVar pins a default session for the current terminal
+const SessionEnvVar = "AI_SESSION"
+
+// SessionMessage is a provider-neutral conversation turn
+type SessionMessage struct {
+	Role      string      `json:"role"` // "user" or "assistant"
+	Content   string      `json:"content"`
+	Provider  string      `json:"provider,omitempty"`
+	Model     string      `json:"model,omitempty"`
+	Timestamp string      `json:"timestamp"`
+	Usage     *TokenUsage `json:"usage,omitempty"`
+}
+
+// TokenUsage is the token count reported by the provider for one response
+type TokenUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Session is a named transcript that any provider can continue
+type Session struct {
+	Name     string           `json:"name"`
+	Created  string           `json:"created"`
+	Updated  string           `json:"updated"`
+	Messages []SessionMessage `json:"messages"`
+}
+
+// OpenSession loads or creates the named session, falling back to $AI_SESSION.
+// Returns nil when no session is requested.
+func OpenSession(name string) (*Session, error) {
+	if name == "" {
+		name = os.Getenv(SessionEnvVar)
+	}
+	if name == "" {
+		return nil, nil
+	}
+	if !regexp.MustCompile(`^[A-Za-z0-9._-]+$`).MatchString(name) {
+		return nil, fmt.Errorf("invalid session name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+
+	data, err := os.ReadFile(sessionFile(name))
+	if os.IsNotExist(err) {
+		now := time.Now().UTC().Format(time.RFC3339)
+		return &Session{Name: name, Created: now, Updated: now}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %v", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %v", name, err)
+	}
+	return &session, nil
+}
+
+// Record appends a user/assistant exchange and saves the session; usage may be nil
+func (s *Session) Record(provider, model, userMessage, response string, usage *TokenUsage) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	s.Messages = append(s.Messages,
+		SessionMessage{Role: "user", Content: userMessage, Timestamp: now},
+		SessionMessage{Role: "assistant", Content: response, Provider: provider, Model: model, Timestamp: now, Usage: usage},
+	)
+	s.Updated = now
+	return s.save()
+}
+
+// Transcript renders the session as plain text for providers without message history
+func (s *Session) Transcript() string {
+	var b strings.Builder
+	for _, m := range s.Messages {
+		role := "User"
+		if m.Role == "assistant" {
+			role = "Assistant"
+		}
+		fmt.Fprintf(&b, "%s: %s\n\n", role, m.Content)
+	}
+	return b.String()
+}
+
+// save replaces the session file atomically
+func (s *Session) save() error {
+	path := sessionFile(s.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %v", err)
+	}
+
+	if err := sys.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session file: %v", err)
+	}
+	return nil
+}
+
+// sessionFile returns the transcript path for a session name
+func sessionFile(name string) string {
+	return filepath.Join(getSessionDir(), name+".json")
+}
+
+// getSessionDir returns the session directory from config
+func getSessionDir() string {
+	cfg, err := config.LoadConfig()
+	if err == nil {
+		return filepath.Join(expandHome(cfg.Cache.BaseDir), "ai", "sessions")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".chatgpt-cli", "sessions")
+}
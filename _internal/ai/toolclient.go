@@ -0,0 +1,65 @@
+package ai
+
+import "fmt"
+
+// ToolClientOptions configures the AI client of a tool
+type ToolClientOptions struct {
+	Tool          string // key for tool_personas and ai.generation in config.yml
+	Provider      string // provider for NewToolClient
+	Persona       string // --persona; empty uses the tool's configured persona
+	DefaultPrompt string // system prompt when no persona applies; empty keeps the client's own
+	NoRedact      bool
+	Generation    *GenerationFlags
+	UseSession    bool   // continue the named session, for clients that support sessions
+	Session       string // --session; empty uses $AI_SESSION
+}
+
+// ToolClient is what SetupToolClient configures; Perplexity has no sessions or chat
+type ToolClient interface {
+	SetPersona(persona *Persona)
+	SetParams(params *GenerationParams)
+	SetRedact(enabled bool)
+}
+
+// NewToolClient creates a stateless provider client set up with SetupToolClient
+func NewToolClient(opts ToolClientOptions) (ProviderClient, error) {
+	client, err := NewProviderClient(opts.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if err := SetupToolClient(client, opts); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// SetupToolClient applies the tool's persona (or default prompt), generation parameters,
+// session and secret redaction to client
+func SetupToolClient(client ToolClient, opts ToolClientOptions) error {
+	persona, err := ResolvePersona(opts.Tool, opts.Persona)
+	if err != nil {
+		return fmt.Errorf("failed to resolve persona: %v", err)
+	}
+	if persona == nil && opts.DefaultPrompt != "" {
+		persona = &Persona{SystemPrompt: opts.DefaultPrompt}
+	}
+	client.SetPersona(persona)
+
+	if opts.Generation != nil {
+		params, err := opts.Generation.Resolve(opts.Tool, persona)
+		if err != nil {
+			return fmt.Errorf("invalid generation parameters: %v", err)
+		}
+		client.SetParams(params)
+	}
+
+	if sessionClient, ok := client.(interface{ SetSession(*Session) }); ok && opts.UseSession {
+		session, err := OpenSession(opts.Session)
+		if err != nil {
+			return fmt.Errorf("failed to open session: %v", err)
+		}
+		sessionClient.SetSession(session)
+	}
+	client.SetRedact(!opts.NoRedact)
+	return nil
+}
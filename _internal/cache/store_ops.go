@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"cli-go/_internal/sys"
 )
 
 // Set stores a key-value pair with tags
@@ -27,7 +29,7 @@ func (s *Store) Set(key string, data map[string]interface{}, tags []string) erro
 	filename := fmt.Sprintf("%s.json", key)
 	filepath := filepath.Join(s.dir, filename)
 
-	if err := os.WriteFile(filepath, jsonData, 0644); err != nil {
+	if err := sys.WriteFileAtomic(filepath, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to write cache file: %v", err)
 	}
 
@@ -16,7 +16,10 @@ import (
 
 // getCredentialsFile returns the credentials file path from config
 func getCredentialsFile() string {
-	cfg, _ := LoadConfig()
+	cfg, err := LoadConfig()
+	if err != nil {
+		return ".cli-go/credentials.enc"
+	}
 
 	return cfg.Credentials.File
 }
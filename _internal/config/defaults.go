@@ -8,6 +8,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// DefaultStatsExclude lists paths left out of gstats when stats.exclude is not configured
+var DefaultStatsExclude = []string{"node_modules/", "vendor/", "dist/", "build/", "coverage/", "third_party/"}
+
 func (c *Config) SetDefaults() {
 	if c.Ringier.DefaultProjectKey == "" {
 		c.Ringier.DefaultProjectKey = "PNT"
@@ -39,6 +42,17 @@ func (c *Config) SetDefaults() {
 		c.Network.RetryAttempts = 3
 	}
 
+	if c.Parallel.Workers == 0 {
+		c.Parallel.Workers = 4
+	}
+	if c.Parallel.RepoTimeoutSeconds == 0 {
+		c.Parallel.RepoTimeoutSeconds = 120
+	}
+
+	if c.Stats.Exclude == nil {
+		c.Stats.Exclude = DefaultStatsExclude
+	}
+
 	if c.Prompts.BaseDir == "" {
 		homeDir, err := os.UserHomeDir()
 		if err == nil {
@@ -116,6 +130,9 @@ func createDefaultConfig() error {
 			Timeouts struct {
 				Default int `json:"default" yaml:"default"`
 			} `json:"timeouts" yaml:"timeouts"`
+			Personas     map[string]PersonaConfig    `json:"personas" yaml:"personas"`
+			ToolPersonas map[string]string           `json:"toolPersonas" yaml:"tool_personas"`
+			Generation   map[string]GenerationConfig `json:"generation" yaml:"generation"`
 		}{
 			Models: struct {
 				OpenAI    string `json:"openai" yaml:"openai"`
@@ -141,6 +158,18 @@ func createDefaultConfig() error {
 			TimeoutSeconds: 30,
 			RetryAttempts:  3,
 		},
+		Parallel: struct {
+			Workers            int `json:"workers" yaml:"workers"`
+			RepoTimeoutSeconds int `json:"repoTimeoutSeconds" yaml:"repo_timeout_seconds"`
+		}{
+			Workers:            4,
+			RepoTimeoutSeconds: 120,
+		},
+		Stats: struct {
+			Exclude []string `json:"exclude" yaml:"exclude"`
+		}{
+			Exclude: DefaultStatsExclude,
+		},
 		Prompts: struct {
 			BaseDir string `json:"baseDir" yaml:"base_dir"`
 		}{
@@ -9,6 +9,23 @@ type RepoConfig struct {
 	Main       bool   `json:"main" yaml:"main"`
 }
 
+// PersonaConfig represents a named AI persona
+type PersonaConfig struct {
+	SystemPrompt string   `json:"systemPrompt" yaml:"system_prompt"`
+	PromptFile   string   `json:"promptFile" yaml:"prompt_file"`
+	Model        string   `json:"model" yaml:"model"`
+	Temperature  *float64 `json:"temperature" yaml:"temperature"`
+}
+
+// GenerationConfig represents default sampling parameters for an AI tool
+type GenerationConfig struct {
+	Temperature *float64 `json:"temperature" yaml:"temperature"`
+	MaxTokens   int      `json:"maxTokens" yaml:"max_tokens"`
+	TopP        *float64 `json:"topP" yaml:"top_p"`
+	Stop        []string `json:"stop" yaml:"stop"`
+	Seed        *int     `json:"seed" yaml:"seed"`
+}
+
 // Config represents the unified configuration
 type Config struct {
 	Ringier struct {
@@ -42,6 +59,9 @@ type Config struct {
 		Timeouts struct {
 			Default int `json:"default" yaml:"default"`
 		} `json:"timeouts" yaml:"timeouts"`
+		Personas     map[string]PersonaConfig    `json:"personas" yaml:"personas"`
+		ToolPersonas map[string]string           `json:"toolPersonas" yaml:"tool_personas"`
+		Generation   map[string]GenerationConfig `json:"generation" yaml:"generation"`
 	} `json:"ai" yaml:"ai"`
 
 	// Network configuration
@@ -50,6 +70,17 @@ type Config struct {
 		RetryAttempts  int `json:"retryAttempts" yaml:"retry_attempts"`
 	} `json:"network" yaml:"network"`
 
+	// Multi-repository execution
+	Parallel struct {
+		Workers            int `json:"workers" yaml:"workers"`
+		RepoTimeoutSeconds int `json:"repoTimeoutSeconds" yaml:"repo_timeout_seconds"`
+	} `json:"parallel" yaml:"parallel"`
+
+	// Repository statistics (gstats)
+	Stats struct {
+		Exclude []string `json:"exclude" yaml:"exclude"`
+	} `json:"stats" yaml:"stats"`
+
 	// Prompts configuration
 	Prompts struct {
 		BaseDir string `json:"baseDir" yaml:"base_dir"`
@@ -1,6 +1,7 @@
 package figma
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -47,10 +48,10 @@ func (c *Client) ValidateToken() error {
 }
 
 // FetchFile fetches the entire Figma file structure
-func (c *Client) FetchFile(fileKey string) (*FigmaFile, error) {
+func (c *Client) FetchFile(ctx context.Context, fileKey string) (*FigmaFile, error) {
 	url := fmt.Sprintf("https://api.figma.com/v1/files/%s", fileKey)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
@@ -76,8 +77,8 @@ func (c *Client) FetchFile(fileKey string) (*FigmaFile, error) {
 }
 
 // FetchComponents extracts all COMPONENT/COMPONENT_SET nodes
-func (c *Client) FetchComponents(fileKey string) ([]Component, error) {
-	file, err := c.FetchFile(fileKey)
+func (c *Client) FetchComponents(ctx context.Context, fileKey string) ([]Component, error) {
+	file, err := c.FetchFile(ctx, fileKey)
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +87,8 @@ func (c *Client) FetchComponents(fileKey string) ([]Component, error) {
 }
 
 // SearchComponents searches for components matching query
-func (c *Client) SearchComponents(fileKey, query string) ([]Component, error) {
-	components, err := c.FetchComponents(fileKey)
+func (c *Client) SearchComponents(ctx context.Context, fileKey, query string) ([]Component, error) {
+	components, err := c.FetchComponents(ctx, fileKey)
 	if err != nil {
 		return nil, err
 	}
@@ -96,8 +97,8 @@ func (c *Client) SearchComponents(fileKey, query string) ([]Component, error) {
 }
 
 // GetComponentByNodeID fetches a component by its node ID
-func (c *Client) GetComponentByNodeID(fileKey, nodeID string) (*Component, error) {
-	components, err := c.FetchComponents(fileKey)
+func (c *Client) GetComponentByNodeID(ctx context.Context, fileKey, nodeID string) (*Component, error) {
+	components, err := c.FetchComponents(ctx, fileKey)
 	if err != nil {
 		return nil, err
 	}
@@ -113,9 +114,9 @@ func (c *Client) GetComponentByNodeID(fileKey, nodeID string) (*Component, error
 }
 
 // GetFullMetadata fetches enhanced metadata for a component
-func (c *Client) GetFullMetadata(fileKey, componentID string) (*EnhancedMetadata, error) {
+func (c *Client) GetFullMetadata(ctx context.Context, fileKey, componentID string) (*EnhancedMetadata, error) {
 	// First get the component
-	component, err := c.GetComponentByNodeID(fileKey, componentID)
+	component, err := c.GetComponentByNodeID(ctx, fileKey, componentID)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +127,6 @@ func (c *Client) GetFullMetadata(fileKey, componentID string) (*EnhancedMetadata
 }
 
 // GetAllComponents fetches all components from a file
-func (c *Client) GetAllComponents(fileKey string) ([]Component, error) {
-	return c.FetchComponents(fileKey)
+func (c *Client) GetAllComponents(ctx context.Context, fileKey string) ([]Component, error) {
+	return c.FetchComponents(ctx, fileKey)
 }
@@ -1,13 +1,14 @@
 package figma
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/io"
 	"os"
 )
 
 // HandleSearch handles search operations
-func HandleSearch(args []string, token string, clip bool, file string, compact, json bool) {
+func HandleSearch(ctx context.Context, args []string, token string, clip bool, file string, compact, json bool) {
 	if len(args) == 0 {
 		outputError("search", "query required", clip, file, compact, json)
 		return
@@ -21,7 +22,7 @@ func HandleSearch(args []string, token string, clip bool, file string, compact,
 
 	// Check if query is a Figma URL
 	if IsFigmaURL(query) {
-		HandleURLLookup(query, fileKey, clip, file, compact, json)
+		HandleURLLookup(ctx, query, fileKey, clip, file, compact, json)
 		return
 	}
 
@@ -39,7 +40,7 @@ func HandleSearch(args []string, token string, clip bool, file string, compact,
 		return
 	}
 
-	freshComponents, err := client.SearchComponents(fileKey, query)
+	freshComponents, err := client.SearchComponents(ctx, fileKey, query)
 	if err != nil {
 		outputError("search", fmt.Sprintf("API error: %v", err), clip, file, compact, json)
 		return
@@ -54,7 +55,7 @@ func HandleSearch(args []string, token string, clip bool, file string, compact,
 }
 
 // HandleURLLookup handles URL lookup operations
-func HandleURLLookup(url, fileKey string, clip bool, file string, compact, json bool) {
+func HandleURLLookup(ctx context.Context, url, fileKey string, clip bool, file string, compact, json bool) {
 	// Extract file key and node ID from URL
 	extractedFileKey, nodeID, err := ExtractFileAndNodeFromURL(url)
 	if err != nil {
@@ -78,7 +79,7 @@ func HandleURLLookup(url, fileKey string, clip bool, file string, compact, json
 
 	// Fetch fresh data
 	client := NewClient("", fileKey)
-	component, err := client.GetComponentByNodeID(fileKey, nodeID)
+	component, err := client.GetComponentByNodeID(ctx, fileKey, nodeID)
 	if err != nil {
 		outputError("url", fmt.Sprintf("API error: %v", err), clip, file, compact, json)
 		return
@@ -93,7 +94,7 @@ func HandleURLLookup(url, fileKey string, clip bool, file string, compact, json
 }
 
 // HandleFullMetadata handles full metadata operations
-func HandleFullMetadata(args []string, token string, clip bool, file string, compact, json bool) {
+func HandleFullMetadata(ctx context.Context, args []string, token string, clip bool, file string, compact, json bool) {
 	if len(args) == 0 {
 		outputError("metadata", "component ID required", clip, file, compact, json)
 		return
@@ -121,7 +122,7 @@ func HandleFullMetadata(args []string, token string, clip bool, file string, com
 		return
 	}
 
-	metadata, err := client.GetFullMetadata(fileKey, componentID)
+	metadata, err := client.GetFullMetadata(ctx, fileKey, componentID)
 	if err != nil {
 		outputError("metadata", fmt.Sprintf("API error: %v", err), clip, file, compact, json)
 		return
@@ -136,7 +137,7 @@ func HandleFullMetadata(args []string, token string, clip bool, file string, com
 }
 
 // HandleInit handles initialization operations
-func HandleInit(args []string, token string, clip bool, file string, compact, json bool) {
+func HandleInit(ctx context.Context, args []string, token string, clip bool, file string, compact, json bool) {
 	fileKey := "Bvw817OVY6zhmEty1Syj8Q" // Default file key
 	if len(args) > 0 {
 		fileKey = args[0]
@@ -150,7 +151,7 @@ func HandleInit(args []string, token string, clip bool, file string, compact, js
 	}
 
 	// Fetch all components
-	components, err := client.GetAllComponents(fileKey)
+	components, err := client.GetAllComponents(ctx, fileKey)
 	if err != nil {
 		outputError("init", fmt.Sprintf("API error: %v", err), clip, file, compact, json)
 		return
@@ -42,27 +42,43 @@ func ReorderAndParse() {
 // Common boolean flags in the CLI tools
 func isBoolFlag(flag string) bool {
 	boolFlags := map[string]bool{
-		"-h":        true,
-		"--json":    true,
-		"--compact": true,
-		"--all":     true,
-		"--main":    true,
-		"--current": true,
-		"-o":        true,
-		"--o":       true,
-		"--open":    true,
-		"--verbose": true,
-		"-v":        true,
-		"--version": true,
-		"--force":   true,
-		"-f":        true,
-		"--dry-run": true,
-		"--quiet":   true,
-		"-q":        true,
-		"--yes":     true,
-		"-y":        true,
-		"--no":      true,
-		"-n":        true,
+		"-h":          true,
+		"--json":      true,
+		"--usage":     true,
+		"--compact":   true,
+		"--all":       true,
+		"--main":      true,
+		"--current":   true,
+		"-o":          true,
+		"--o":         true,
+		"--open":      true,
+		"--verbose":   true,
+		"-v":          true,
+		"--version":   true,
+		"--force":     true,
+		"-f":          true,
+		"--dry-run":   true,
+		"--quiet":     true,
+		"-q":          true,
+		"--yes":       true,
+		"-y":          true,
+		"--no":        true,
+		"-n":          true,
+		"--no-redact": true,
+		"--clip":      true,
+		"--ai":        true,
+		"--no-jira":   true,
+		"--no-github": true,
+
+		"--include-generated": true,
+		"--no-cache":          true,
+		"--stat":              true,
+		"--diff":              true,
+		"--side":              true,
+		"--untracked":         true,
+		"--remote":            true,
+		"--delete":            true,
+		"--install":           true,
 	}
 
 	// Remove leading dashes for lookup
@@ -0,0 +1,273 @@
+package git
+
+import (
+	"fmt"
+	"cli-go/_internal/config"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BaseBranch is the branch the current branch was forked from and why it was chosen
+type BaseBranch struct {
+	Branch    string `json:"branch"`
+	ForkPoint string `json:"fork_point"`
+	Distance  int    `json:"distance"` // commits on HEAD since the fork point
+	Reason    string `json:"reason"`
+}
+
+// longLivedBranches are the usual integration branch names, in order of preference
+var longLivedBranches = []string{"main", "master", "develop", "development", "staging", "trunk"}
+
+// maxFeatureCandidates limits how many recently updated remote branches are considered
+const maxFeatureCandidates = 20
+
+// baseConfigKey stores a base set with --base per branch in the repository's git config,
+// so gaff, gcm and friends agree until it is overridden again
+func baseConfigKey(branch string) string {
+	return "branch." + branch + ".forkBase"
+}
+
+// ResolveBaseBranch returns the base branch of the current branch. A non-empty override
+// (branch name or ref) wins and is remembered; otherwise a base remembered from --base is
+// reused while it is still valid, and only then is the base detected. Detected bases are not
+// stored, so read-only runs leave the git config alone and a wrong guess is not kept.
+func ResolveBaseBranch(override string) (*BaseBranch, error) {
+	current, _ := GetCurrentBranch("")
+	if current == "HEAD" {
+		current = "" // detached: nothing to remember the decision under
+	}
+
+	if override != "" {
+		ref, ok := resolveBranchRef(override)
+		if !ok {
+			return nil, fmt.Errorf("base branch not found: %s", override)
+		}
+		base, ok := measureBase(ref)
+		if !ok {
+			return nil, fmt.Errorf("%s has no common history with HEAD", ref)
+		}
+		base.Reason = "set with --base"
+		rememberBase(current, ref)
+		return base, nil
+	}
+
+	if current != "" {
+		result := sys.RunCommand("git", "config", "--get", baseConfigKey(current))
+		if result.ExitCode == 0 && result.Stdout != "" {
+			if base, ok := measureBase(result.Stdout); ok {
+				base.Reason = "remembered for " + current
+				return base, nil
+			}
+		}
+	}
+
+	return detectBaseBranch(current)
+}
+
+// ForgetBaseBranch drops the remembered base of a branch
+func ForgetBaseBranch(branch string) {
+	sys.RunCommand("git", "config", "--unset", baseConfigKey(branch))
+}
+
+func rememberBase(branch, ref string) {
+	if branch != "" {
+		sys.RunCommand("git", "config", baseConfigKey(branch), ref)
+	}
+}
+
+// detectBaseBranch picks the closest long-lived branch (configured main branch first), then
+// checks whether a recently updated feature branch is an even closer base (a stacked branch).
+// Feature branches forked from our own commits are ignored, as are descendants of HEAD.
+func detectBaseBranch(current string) (*BaseBranch, error) {
+	upstream := sys.RunCommand("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}").Stdout
+	own := func(ref string) bool {
+		return ref == upstream || (current != "" && (ref == current || ref == "origin/"+current))
+	}
+
+	var best *BaseBranch
+	bestCommits := 0
+	seen := make(map[string]bool)
+
+	configured := configuredMainBranch()
+	candidates := longLivedBranches
+	if configured != "" {
+		candidates = append([]string{configured}, longLivedBranches...)
+	}
+	for _, name := range candidates {
+		ref, ok := resolveBranchRef(name)
+		if !ok || seen[ref] || own(ref) {
+			continue
+		}
+		seen[ref] = true
+		base, ok := measureBase(ref)
+		if !ok {
+			continue
+		}
+		// Prefer the closest fork point, then the branch with more history (the long-lived one)
+		commits := countCommits(ref)
+		if best == nil || base.Distance < best.Distance || (base.Distance == best.Distance && commits > bestCommits) {
+			best, bestCommits = base, commits
+			best.Reason = fmt.Sprintf("closest long-lived branch, %s since fork point", io.Plural(base.Distance, "commit"))
+			if name == configured {
+				best.Reason = fmt.Sprintf("configured main branch, %s since fork point", io.Plural(base.Distance, "commit"))
+			}
+		}
+	}
+
+	email := authorEmail()
+	for _, ref := range recentRemoteBranches() {
+		if seen[ref] || own(ref) {
+			continue
+		}
+		base, ok := measureBase(ref)
+		if !ok || (best != nil && base.Distance >= best.Distance) {
+			continue
+		}
+		if containsHead(ref) || (best != nil && forkedFromUs(best.ForkPoint, base.ForkPoint, email)) {
+			continue
+		}
+		best = base
+		best.Reason = fmt.Sprintf("stacked on %s, %s since fork point", ref, io.Plural(base.Distance, "commit"))
+	}
+
+	// A long-lived branch without another candidate (e.g. main itself) falls back to its upstream
+	if best == nil && upstream != "" {
+		if base, ok := measureBase(upstream); ok {
+			base.Reason = "upstream of " + current
+			return base, nil
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("could not determine base branch (use --base)")
+	}
+	return best, nil
+}
+
+// configuredMainBranch returns RepoConfig.MainBranch for the current repository, if configured
+func configuredMainBranch() string {
+	root, err := GetGitRoot()
+	if err != nil {
+		return ""
+	}
+	return configuredMainBranchAt(root)
+}
+
+// configuredMainBranchAt returns RepoConfig.MainBranch for the repository at repoPath, if configured
+func configuredMainBranchAt(repoPath string) string {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return ""
+	}
+	for _, repo := range cfg.Repositories {
+		if repo.MainBranch != "" && filepath.Clean(repo.Path) == filepath.Clean(repoPath) {
+			return repo.MainBranch
+		}
+	}
+	return ""
+}
+
+// MainBranchAt returns the main branch of the repository at repoPath: the configured main_branch,
+// else the branch origin/HEAD points to, else the first existing of main and master
+func MainBranchAt(repoPath string) string {
+	if branch := configuredMainBranchAt(repoPath); branch != "" {
+		return branch
+	}
+	result := sys.RunCommandInDir(repoPath, "git", "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if result.ExitCode == 0 && strings.HasPrefix(result.Stdout, "origin/") {
+		return strings.TrimPrefix(result.Stdout, "origin/")
+	}
+	for _, branch := range []string{"main", "master"} {
+		for _, ref := range []string{"refs/heads/" + branch, "refs/remotes/origin/" + branch} {
+			if sys.RunCommandInDir(repoPath, "git", "show-ref", "-q", "--verify", ref).ExitCode == 0 {
+				return branch
+			}
+		}
+	}
+	return "main"
+}
+
+// IsLongLivedBranch reports whether branch is the main branch of the repository or one of the
+// usual integration branches (main, master, develop, ...), which are not expected to have a PR
+func IsLongLivedBranch(repoPath, branch string) bool {
+	return Contains(longLivedBranches, branch) || branch == MainBranchAt(repoPath)
+}
+
+// resolveBranchRef prefers the remote-tracking branch, so the base is as fresh as the last fetch
+func resolveBranchRef(name string) (string, bool) {
+	for _, ref := range []string{"origin/" + strings.TrimPrefix(name, "origin/"), name} {
+		if sys.RunCommand("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").ExitCode == 0 {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+// measureBase computes the fork point of HEAD and ref and how many commits HEAD has since then
+func measureBase(ref string) (*BaseBranch, bool) {
+	mergeBase := sys.RunCommand("git", "merge-base", "HEAD", ref)
+	if mergeBase.ExitCode != 0 || mergeBase.Stdout == "" {
+		return nil, false
+	}
+	count := sys.RunCommand("git", "rev-list", "--count", mergeBase.Stdout+"..HEAD")
+	distance, err := strconv.Atoi(count.Stdout)
+	if count.ExitCode != 0 || err != nil {
+		return nil, false
+	}
+	return &BaseBranch{Branch: ref, ForkPoint: mergeBase.Stdout, Distance: distance}, true
+}
+
+func countCommits(ref string) int {
+	count, _ := strconv.Atoi(sys.RunCommand("git", "rev-list", "--count", ref).Stdout)
+	return count
+}
+
+// recentRemoteBranches returns the most recently updated branches on origin
+func recentRemoteBranches() []string {
+	result := sys.RunCommand("git", "for-each-ref", "--sort=-committerdate", fmt.Sprintf("--count=%d", maxFeatureCandidates+1),
+		"--format=%(refname:short)", "refs/remotes/origin")
+	if result.ExitCode != 0 {
+		return nil
+	}
+	var refs []string
+	for _, ref := range strings.Split(result.Stdout, "\n") {
+		if ref != "" && ref != "origin/HEAD" && ref != "origin" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// containsHead reports whether ref already contains HEAD, i.e. it was branched from us
+func containsHead(ref string) bool {
+	return sys.RunCommand("git", "merge-base", "--is-ancestor", "HEAD", ref).ExitCode == 0
+}
+
+// forkedFromUs reports whether every commit between the long-lived fork point and a feature
+// branch's fork point is our own, i.e. that branch was forked from our work and not the other way round
+func forkedFromUs(longLivedForkPoint, featureForkPoint, email string) bool {
+	if email == "" {
+		return false
+	}
+	result := sys.RunCommand("git", "log", "--format=%ae", longLivedForkPoint+".."+featureForkPoint)
+	if result.ExitCode != 0 || result.Stdout == "" {
+		return false
+	}
+	for _, author := range strings.Split(result.Stdout, "\n") {
+		if !strings.EqualFold(strings.TrimSpace(author), email) {
+			return false
+		}
+	}
+	return true
+}
+
+// authorEmail returns the email new commits are authored with (config or GIT_AUTHOR_EMAIL)
+func authorEmail() string {
+	ident := sys.RunCommand("git", "var", "GIT_AUTHOR_IDENT").Stdout
+	if start, end := strings.Index(ident, "<"), strings.Index(ident, ">"); start >= 0 && end > start {
+		return ident[start+1 : end]
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package git
+
+import (
+	"context"
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"cli-go/_internal/cache"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"path/filepath"
+	"strings"
+)
+
+// Blob is a file in a commit's tree
+type Blob struct {
+	Path   string `json:"path"`
+	Hash   string `json:"hash"`
+	Commit string `json:"commit"`
+}
+
+// AuthorLines is the number of lines attributed to one author
+type AuthorLines struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Lines int    `json:"lines"`
+}
+
+// GetSourceBlobs lists the source files at HEAD with their blob hashes, skipping
+// excluded paths and files that are generated by name (lock files, *.min.js, ...)
+func GetSourceBlobs(ctx context.Context, repoPath string, exclude []string) ([]Blob, error) {
+	head := sys.RunCommandInDirContext(ctx, repoPath, "git", "rev-parse", "HEAD")
+	if head.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to resolve HEAD: %s", head.Stderr)
+	}
+
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "ls-tree", "-r", "-z", head.Stdout)
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list files at HEAD: %s", result.Stderr)
+	}
+
+	var blobs []Blob
+	for _, entry := range strings.Split(result.Stdout, "\x00") {
+		// <mode> <type> <hash>\t<path>
+		meta, path, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+		if !validExts[ext] || IsExcluded(path, exclude) || isGeneratedName(path) {
+			continue
+		}
+		blobs = append(blobs, Blob{Path: path, Hash: fields[2], Commit: head.Stdout})
+	}
+	return blobs, nil
+}
+
+// BlameBlob returns the surviving lines per author of a file at its commit.
+// Results are cached by repository, path and blob hash, so unchanged files are not blamed again.
+func BlameBlob(ctx context.Context, repoPath string, blob Blob, store *cache.Store) ([]AuthorLines, error) {
+	hash := sha1.Sum([]byte(strings.Join([]string{repoPath, blob.Path, blob.Hash}, "\x00")))
+	key := "blame-" + hex.EncodeToString(hash[:])
+	if store != nil {
+		if entry, err := store.Get(key); err == nil {
+			if authors, ok := authorLinesFromCache(entry.Data); ok {
+				return authors, nil
+			}
+		}
+	}
+
+	// -w ignores whitespace-only changes, so reformatting doesn't transfer ownership
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "blame", "--line-porcelain", "-w", blob.Commit, "--", blob.Path)
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to blame %s: %s", blob.Path, strings.TrimSpace(result.Stderr))
+	}
+	authors := parseLinePorcelain(result.Stdout)
+
+	if store != nil {
+		data := map[string]interface{}{"path": blob.Path, "authors": authors}
+		if err := store.Set(key, data, []string{"blame"}); err != nil {
+			io.LogWarning("failed to cache blame of %s: %v", blob.Path, err)
+		}
+	}
+	return authors, nil
+}
+
+// parseLinePorcelain counts lines per author email in `git blame --line-porcelain` output
+func parseLinePorcelain(output string) []AuthorLines {
+	authors := []AuthorLines{}
+	index := make(map[string]int)
+	name := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "author "):
+			name = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-mail "):
+			email := strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+			i, ok := index[email]
+			if !ok {
+				i = len(authors)
+				index[email] = i
+				authors = append(authors, AuthorLines{Name: name, Email: email})
+			}
+			authors[i].Lines++
+		}
+	}
+	return authors
+}
+
+// authorLinesFromCache decodes cached blame data (numbers come back as float64)
+func authorLinesFromCache(data map[string]interface{}) ([]AuthorLines, bool) {
+	list, ok := data["authors"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	authors := make([]AuthorLines, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, _ := m["name"].(string)
+		email, _ := m["email"].(string)
+		lines, _ := m["lines"].(float64)
+		authors = append(authors, AuthorLines{Name: name, Email: email, Lines: int(lines)})
+	}
+	return authors, true
+}
+
+// DirOf returns the first depth directories of a repository-relative path ("." for top-level files)
+func DirOf(relPath string, depth int) string {
+	dir := filepath.ToSlash(filepath.Dir(relPath))
+	if dir == "." {
+		return dir
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
@@ -1,8 +1,12 @@
 package git
 
 import (
+	"context"
 	"fmt"
+	"cli-go/_internal/github"
+	"cli-go/_internal/io"
 	"cli-go/_internal/sys"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -23,12 +27,16 @@ type BranchOperationResult struct {
 
 // Branch represents a Git branch with metadata
 type Branch struct {
-	Name       string    `json:"name"`
-	LastCommit time.Time `json:"lastCommit"`
-	Author     string    `json:"author"`
-	RepoPath   string    `json:"repoPath"`
-	HasPR      bool      `json:"hasPR"`
-	PRNumber   int       `json:"prNumber,omitempty"`
+	Name           string    `json:"name"`
+	LastCommit     time.Time `json:"lastCommit"`
+	Author         string    `json:"author"`
+	RepoPath       string    `json:"repoPath"`
+	HasPR          bool      `json:"hasPR"`
+	PRNumber       int       `json:"prNumber,omitempty"`
+	PRState        string    `json:"prState,omitempty"` // OPEN, CLOSED, MERGED
+	PRDraft        bool      `json:"prDraft,omitempty"`
+	ReviewDecision string    `json:"reviewDecision,omitempty"`
+	PRURL          string    `json:"prUrl,omitempty"`
 }
 
 // GetMainBranch returns the main branch name (main, master, etc.)
@@ -62,45 +70,36 @@ func GetMainBranch() (string, error) {
 }
 
 // GetBranchCount returns the number of local branches
-func GetBranchCount(repoPath string) int {
-	result := sys.RunCommandInDir(repoPath, "git", "branch")
+func GetBranchCount(ctx context.Context, repoPath string) int {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "branch")
 	if result.ExitCode != 0 {
 		return 0
 	}
 	return len(strings.Split(result.Stdout, "\n")) - 1
 }
 
-// ListBranches returns all branches in the repository
+// ListBranches returns the local and origin branches of the repository, without the origin/ prefix
 func ListBranches(repoPath string) ([]string, error) {
-	result := sys.RunCommand("git", "-C", repoPath, "branch", "-a")
+	// for-each-ref instead of git branch, whose output marks branches with "* " or "+ " (other worktree)
+	result := sys.RunCommand("git", "-C", repoPath, "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes/origin")
 	if result.ExitCode != 0 {
 		return nil, fmt.Errorf("failed to get branches: %s", result.Stderr)
 	}
 
 	var branches []string
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		// Remove * prefix and remote/ prefix
-		line = strings.TrimPrefix(line, "* ")
-		line = strings.TrimPrefix(line, "remotes/origin/")
-		line = strings.TrimPrefix(line, "origin/")
-
-		if line != "" && !strings.Contains(line, "HEAD") {
-			branches = append(branches, line)
+	for _, ref := range strings.Split(result.Stdout, "\n") {
+		name := strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/remotes/origin/")
+		if name != "" && name != "HEAD" {
+			branches = append(branches, name)
 		}
 	}
 
-	return branches, nil
+	// A branch that exists locally and on origin is listed once
+	return RemoveDuplicates(branches), nil
 }
 
 // GetUserBranches returns branches created by a specific author
-func GetUserBranches(repoPath, authorEmail string) ([]Branch, error) {
+func GetUserBranches(ctx context.Context, repoPath, authorEmail string) ([]Branch, error) {
 	// Get all branches
 	branches, err := ListBranches(repoPath)
 	if err != nil {
@@ -109,8 +108,11 @@ func GetUserBranches(repoPath, authorEmail string) ([]Branch, error) {
 
 	var userBranches []Branch
 	for _, branchName := range branches {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		// Get last commit info for this branch
-		lastCommit, author, err := GetBranchLastCommit(repoPath, branchName)
+		lastCommit, author, err := GetBranchLastCommit(ctx, repoPath, branchName)
 		if err != nil {
 			continue // Skip branches we can't analyze
 		}
@@ -131,9 +133,9 @@ func GetUserBranches(repoPath, authorEmail string) ([]Branch, error) {
 }
 
 // GetBranchLastCommit returns the last commit info for a branch
-func GetBranchLastCommit(repoPath, branch string) (time.Time, string, error) {
+func GetBranchLastCommit(ctx context.Context, repoPath, branch string) (time.Time, string, error) {
 	// Get last commit date and author
-	result := sys.RunCommand("git", "-C", repoPath, "log", "-1", "--format=%ad|%ae", "--date=iso", branch)
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "log", "-1", "--format=%ad|%ae", "--date=iso", branch)
 	if result.ExitCode != 0 {
 		return time.Time{}, "", fmt.Errorf("failed to get last commit for branch %s: %s", branch, result.Stderr)
 	}
@@ -159,11 +161,35 @@ func GetBranchLastCommit(repoPath, branch string) (time.Time, string, error) {
 	return date, author, nil
 }
 
-// CheckBranchHasPR checks if a branch has an associated PR (placeholder for GitHub API integration)
-func CheckBranchHasPR(repoPath, branch string) (bool, int, error) {
-	// This would need GitHub API integration to check for PRs
-	// For now, return false - this will be implemented when integrating with gh API
-	return false, 0, nil
+// ListBranchPRs returns the PRs of a repository keyed by head branch (see github.ListPRsByHead)
+// and warns when only the most recent PRs could be checked
+func ListBranchPRs(ctx context.Context, repoPath string) (map[string]github.PR, error) {
+	slug, err := GetRepoSlug(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	owner, repo := github.SplitSlug(slug)
+	prs, truncated, err := github.ListPRsByHead(ctx, owner, repo)
+	if truncated {
+		io.LogWarning("%s: only the %d most recent PRs were checked, older branches may show no PR", filepath.Base(repoPath), github.PRListLimit)
+	}
+	return prs, err
+}
+
+// AttachPRs fills the PR fields of branches from prs, the PRs of their repository by head branch
+func AttachPRs(branches []Branch, prs map[string]github.PR) {
+	for i := range branches {
+		pr, ok := prs[strings.TrimPrefix(branches[i].Name, "origin/")]
+		if !ok {
+			continue
+		}
+		branches[i].HasPR = true
+		branches[i].PRNumber = pr.Number
+		branches[i].PRState = pr.State
+		branches[i].PRDraft = pr.IsDraft
+		branches[i].ReviewDecision = pr.ReviewDecision
+		branches[i].PRURL = pr.URL
+	}
 }
 
 // DeleteBranch deletes a branch
@@ -1,10 +1,10 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/custom"
 	"cli-go/_internal/sys"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -12,120 +12,170 @@ import (
 
 // Commit represents a Git commit with metadata
 type Commit struct {
-	Hash         string    `json:"hash"`
-	Message      string    `json:"message"`
-	Author       string    `json:"author"`
-	Date         time.Time `json:"date"`
-	Repository   string    `json:"repository"`
-	Branch       string    `json:"branch"`
-	TicketIDs    []string  `json:"ticketIds"`
-	FilesAdded   int       `json:"filesAdded"`
-	FilesDeleted int       `json:"filesDeleted"`
-	LinesAdded   int       `json:"linesAdded"`
-	LinesDeleted int       `json:"linesDeleted"`
+	Hash         string     `json:"hash"`
+	Message      string     `json:"message"`
+	Author       string     `json:"author"`
+	Date         time.Time  `json:"date"`
+	Repository   string     `json:"repository"`
+	Branch       string     `json:"branch"`
+	TicketIDs    []string   `json:"ticketIds"`
+	FilesAdded   int        `json:"filesAdded"`
+	FilesDeleted int        `json:"filesDeleted"`
+	FilesChanged int        `json:"filesChanged"`
+	LinesAdded   int        `json:"linesAdded"`
+	LinesDeleted int        `json:"linesDeleted"`
+	Files        []FileStat `json:"files,omitempty"`
 }
 
-// GetCommits gets commits from a repository since a specific time
-func GetCommits(repoPath string, since time.Time, author string) ([]Commit, error) {
-	// Check if it's a git repository
-	if !IsGitRepo() {
+// FileStat holds the line changes of one file in a commit (binary files count 0)
+type FileStat struct {
+	Path    string `json:"path"`
+	OldPath string `json:"oldPath,omitempty"` // set for renames
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+}
+
+// LogOptions filters the commits returned by GetCommits
+type LogOptions struct {
+	Range    string    // revision range, e.g. v1.2.0..v1.3.0 (default: HEAD)
+	All      bool      // all local and remote branches instead of Range
+	Since    time.Time // zero means no lower bound
+	Until    time.Time // zero means no upper bound
+	Author   string    // author name or email pattern
+	Grep     string    // commit message pattern
+	Paths    []string  // only commits touching these paths
+	NoMerges bool
+}
+
+// logFormat starts every commit with a record separator so numstat lines can be grouped
+const logFormat = "--pretty=format:\x1e%H\x1f%an\x1f%aI\x1f%S\x1f%s"
+
+// GetCommits gets the commits of a repository with branch and file stats in a single git log pass
+func GetCommits(ctx context.Context, repoPath string, opts LogOptions) ([]Commit, error) {
+	if !IsGitRepoAtPath(repoPath) {
 		return nil, fmt.Errorf("not a git repository: %s", repoPath)
 	}
 
-	// Build git log command
-	args := []string{"log", "--since", since.Format("2006-01-02"), "--pretty=format:%H|%an|%ae|%ad|%s", "--date=iso"}
-	if author != "" {
-		args = append(args, "--author", author)
+	args := []string{"log", logFormat, "--source", "--numstat", "--summary"}
+	if opts.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if !opts.Since.IsZero() {
+		args = append(args, "--since", opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		args = append(args, "--until", opts.Until.Format(time.RFC3339))
+	}
+	if opts.Author != "" {
+		args = append(args, "--author", opts.Author)
+	}
+	if opts.Grep != "" {
+		args = append(args, "--grep", opts.Grep, "--regexp-ignore-case")
+	}
+	if opts.All {
+		args = append(args, "--all")
+	} else if opts.Range != "" {
+		args = append(args, opts.Range)
+	}
+	if len(opts.Paths) > 0 {
+		args = append(append(args, "--"), opts.Paths...)
 	}
 
-	result := sys.RunCommand("git", args...)
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", args...)
 	if result.ExitCode != 0 {
 		return nil, fmt.Errorf("failed to get commits: %s", result.Stderr)
 	}
 
-	var commits []Commit
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
+	// Without --all every commit is reached from HEAD, so use the checked out branch name
+	headBranch, _ := GetCurrentBranch(repoPath)
 
-	for _, line := range lines {
-		if line == "" {
-			continue
+	var commits []Commit
+	for _, record := range strings.Split(result.Stdout, "\x1e") {
+		if commit, ok := parseCommitRecord(record, repoPath, headBranch); ok {
+			commits = append(commits, commit)
 		}
+	}
 
-		parts := strings.Split(line, "|")
-		if len(parts) < 5 {
-			continue
-		}
+	return commits, nil
+}
 
-		hash := parts[0]
-		authorName := parts[1]
-		_ = parts[2] // authorEmail - not used
-		dateStr := parts[3]
-		message := parts[4]
-
-		date, err := time.Parse("2006-01-02 15:04:05 -0700", dateStr)
-		if err != nil {
-			// Try alternative format
-			date, err = time.Parse("2006-01-02T15:04:05-07:00", dateStr)
-			if err != nil {
-				continue
+// renamePaths splits a numstat rename ("src/{a => b}/x.go" or "a.go => b.go") into old and new path;
+// paths that weren't renamed are returned as the new path
+func renamePaths(path string) (string, string) {
+	if open, close := strings.Index(path, "{"), strings.LastIndex(path, "}"); open >= 0 && close > open {
+		if from, to, ok := strings.Cut(path[open+1:close], " => "); ok {
+			prefix, suffix := path[:open], path[close+1:]
+			join := func(middle string) string {
+				return strings.ReplaceAll(prefix+middle+suffix, "//", "/")
 			}
+			return join(from), join(to)
 		}
+	}
+	if from, to, ok := strings.Cut(path, " => "); ok {
+		return from, to
+	}
+	return "", path
+}
 
-		// Get branch name
-		branch, _ := GetCurrentBranch()
-
-		// Extract ticket IDs from message
-		tickets := custom.ExtractTicketsFromMessage(message)
-
-		// Get diff stats
-		added, deleted, _ := GetDiffStats(repoPath, hash)
-
-		commit := Commit{
-			Hash:         hash,
-			Message:      message,
-			Author:       authorName,
-			Date:         date,
-			Repository:   repoPath,
-			Branch:       branch,
-			TicketIDs:    tickets,
-			LinesAdded:   added,
-			LinesDeleted: deleted,
-		}
+// parseCommitRecord parses one header line followed by its --numstat and --summary lines
+func parseCommitRecord(record, repoPath, headBranch string) (Commit, bool) {
+	lines := strings.Split(strings.TrimSpace(record), "\n")
+	parts := strings.Split(lines[0], "\x1f")
+	if len(parts) < 5 {
+		return Commit{}, false
+	}
 
-		commits = append(commits, commit)
+	date, _ := time.Parse(time.RFC3339, parts[2])
+	commit := Commit{
+		Hash:       parts[0],
+		Author:     parts[1],
+		Date:       date,
+		Repository: repoPath,
+		Branch:     sourceBranch(parts[3], headBranch),
+		Message:    parts[4],
+		TicketIDs:  custom.ExtractTicketsFromMessage(parts[4]),
 	}
 
-	return commits, nil
+	for _, line := range lines[1:] {
+		if fields := strings.SplitN(line, "\t", 3); len(fields) == 3 {
+			added, _ := strconv.Atoi(fields[0]) // "-" for binary files
+			deleted, _ := strconv.Atoi(fields[1])
+			oldPath, path := renamePaths(fields[2])
+			commit.Files = append(commit.Files, FileStat{Path: path, OldPath: oldPath, Added: added, Deleted: deleted})
+			commit.LinesAdded += added
+			commit.LinesDeleted += deleted
+			commit.FilesChanged++
+			continue
+		}
+		switch line = strings.TrimSpace(line); {
+		case strings.HasPrefix(line, "create mode "):
+			commit.FilesAdded++
+		case strings.HasPrefix(line, "delete mode "):
+			commit.FilesDeleted++
+		}
+	}
+
+	return commit, true
 }
 
-// GetDiffStats gets the diff statistics for a commit
-func GetDiffStats(repoPath, commitHash string) (added, deleted int, err error) {
-	if !IsGitRepo() {
-		return 0, 0, fmt.Errorf("not a git repository: %s", repoPath)
+// sourceBranch turns the ref a commit was reached from into a branch name
+func sourceBranch(source, headBranch string) string {
+	switch {
+	case source == "HEAD" || source == "":
+		return headBranch
+	case strings.HasPrefix(source, "refs/heads/"):
+		return strings.TrimPrefix(source, "refs/heads/")
+	case strings.HasPrefix(source, "refs/remotes/"):
+		return strings.TrimPrefix(source, "refs/remotes/")
 	}
+	return strings.TrimPrefix(source, "refs/")
+}
 
-	result := sys.RunCommand("git", "show", "--stat", "--format=", commitHash)
-	if result.ExitCode != 0 {
-		return 0, 0, fmt.Errorf("failed to get diff stats: %s", result.Stderr)
-	}
-
-	// Parse the diff stats output
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "insertion") || strings.Contains(line, "deletion") {
-			// Parse lines like " 2 files changed, 3 insertions(+), 1 deletion(-)"
-			re := regexp.MustCompile(`(\d+) insertion.*?(\d+) deletion`)
-			matches := re.FindStringSubmatch(line)
-			if len(matches) == 3 {
-				if ins, err := strconv.Atoi(matches[1]); err == nil {
-					added += ins
-				}
-				if del, err := strconv.Atoi(matches[2]); err == nil {
-					deleted += del
-				}
-			}
-		}
+// GetCommitsInRange gets the non-merge commits of a revision range (e.g. v1.2.0..v1.3.0) in a repository
+func GetCommitsInRange(ctx context.Context, repoPath, revRange string) ([]Commit, error) {
+	commits, err := GetCommits(ctx, repoPath, LogOptions{Range: revRange, NoMerges: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits for %s: %v", revRange, err)
 	}
-
-	return added, deleted, nil
+	return commits, nil
 }
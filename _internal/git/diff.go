@@ -0,0 +1,186 @@
+package git
+
+import (
+	"fmt"
+	"cli-go/_internal/sys"
+	"strconv"
+	"strings"
+)
+
+// FileChange is one file changed between a commit and the working tree
+type FileChange struct {
+	Path    string `json:"path"`
+	OldPath string `json:"old_path,omitempty"` // set for renames and copies
+	Status  string `json:"status"`             // added, modified, deleted, renamed, copied, typechange
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+	Binary  bool   `json:"binary,omitempty"`
+}
+
+// ChangeFilter selects changes by path pattern and status; empty fields match everything
+type ChangeFilter struct {
+	Paths    []string // patterns as in MatchesAnyPattern
+	Statuses []string // added, modified, deleted, renamed (or a, m, d, r)
+}
+
+// statusNames maps git's --name-status letters to FileChange.Status
+var statusNames = map[byte]string{
+	'A': "added", 'M': "modified", 'D': "deleted", 'R': "renamed", 'C': "copied", 'T': "typechange",
+}
+
+// Match reports whether a change passes the filter
+func (f ChangeFilter) Match(change FileChange) bool {
+	if len(f.Statuses) > 0 {
+		matched := false
+		for _, status := range f.Statuses {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if status != "" && strings.HasPrefix(change.Status, status) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(f.Paths) > 0 {
+		return MatchesAnyPattern(change.Path, f.Paths) || (change.OldPath != "" && MatchesAnyPattern(change.OldPath, f.Paths))
+	}
+	return true
+}
+
+// FilterChanges returns the changes that pass the filter
+func FilterChanges(changes []FileChange, filter ChangeFilter) []FileChange {
+	var result []FileChange
+	for _, change := range changes {
+		if filter.Match(change) {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// GetChangesSince returns the files changed between a commit and the working tree with their
+// status and line counts; renames are detected
+func GetChangesSince(commit string) ([]FileChange, error) {
+	return readChanges([]string{"diff"}, commit)
+}
+
+// readChanges runs a diff command (git diff, git stash show) for rev with --name-status and
+// --numstat and combines the two
+func readChanges(command []string, rev string) ([]FileChange, error) {
+	run := func(format string) *sys.ExecResult {
+		args := append(append([]string{}, command...), "-z", "-M", format, rev)
+		return sys.RunCommand("git", args...)
+	}
+	nameStatus := run("--name-status")
+	if nameStatus.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to get changed files: %s", nameStatus.Stderr)
+	}
+	numstat := run("--numstat")
+	if numstat.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to get diff stats: %s", numstat.Stderr)
+	}
+
+	// --name-status -z: <status>\0<path>\0, or <status>\0<old>\0<new>\0 for renames and copies
+	var changes []FileChange
+	index := make(map[string]int)
+	fields := strings.Split(nameStatus.Stdout, "\x00")
+	for i := 0; i < len(fields); i++ {
+		code := strings.TrimSpace(fields[i])
+		if code == "" || i+1 >= len(fields) {
+			continue
+		}
+		change := FileChange{Status: statusNames[code[0]], Path: fields[i+1]}
+		if change.Status == "" {
+			change.Status = strings.ToLower(code)
+		}
+		i++
+		if (code[0] == 'R' || code[0] == 'C') && i+1 < len(fields) {
+			change.OldPath, change.Path = change.Path, fields[i+1]
+			i++
+		}
+		index[change.Path] = len(changes)
+		changes = append(changes, change)
+	}
+
+	// --numstat -z: <added>\t<deleted>\t<path>\0, or <added>\t<deleted>\t\0<old>\0<new>\0 for renames
+	fields = strings.Split(numstat.Stdout, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(strings.TrimLeft(fields[i], "\n"), "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := parts[2]
+		if path == "" && i+2 < len(fields) {
+			path = fields[i+2]
+			i += 2
+		}
+		j, ok := index[path]
+		if !ok {
+			continue
+		}
+		if parts[0] == "-" {
+			changes[j].Binary = true
+			continue
+		}
+		changes[j].Added, _ = strconv.Atoi(parts[0])
+		changes[j].Deleted, _ = strconv.Atoi(parts[1])
+	}
+
+	return changes, nil
+}
+
+// GetDiffSince returns the unified diff between a commit and the working tree, limited to paths
+// when given; binary includes binary content so the patch can be applied with git apply
+func GetDiffSince(commit string, paths []string, binary bool) (string, error) {
+	args := []string{"diff", "-M", "--no-color", "--no-ext-diff"}
+	if binary {
+		args = append(args, "--binary")
+	}
+	args = append(append(args, commit, "--"), paths...)
+
+	// Untrimmed: a patch ending in a blank context line is corrupt without it
+	result := sys.RunCommandRaw("git", args...)
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("failed to get diff: %s", result.Stderr)
+	}
+	return result.Stdout, nil
+}
+
+// FormatChangeStat formats changes since the fork point of base as a markdown table with line counts
+func FormatChangeStat(changes []FileChange, base *BaseBranch) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("## Changes since %s (%s)\n\n", base.Branch, base.Reason))
+	output.WriteString("| Status | File | Added | Deleted |\n")
+	output.WriteString("|---|---|--:|--:|\n")
+
+	added, deleted := 0, 0
+	for _, change := range changes {
+		path := change.Path
+		if change.OldPath != "" {
+			path = change.OldPath + " → " + change.Path
+		}
+		if change.Binary {
+			output.WriteString(fmt.Sprintf("| %s | %s | binary | binary |\n", change.Status, path))
+			continue
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | +%d | -%d |\n", change.Status, path, change.Added, change.Deleted))
+		added += change.Added
+		deleted += change.Deleted
+	}
+	output.WriteString(fmt.Sprintf("| | %d files | +%d | -%d |\n", len(changes), added, deleted))
+	return output.String()
+}
+
+// ChangePaths returns the paths of the changes, including the old path of renames
+func ChangePaths(changes []FileChange) []string {
+	var paths []string
+	for _, change := range changes {
+		if change.OldPath != "" {
+			paths = append(paths, change.OldPath)
+		}
+		paths = append(paths, change.Path)
+	}
+	return paths
+}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/sys"
 	"strings"
@@ -49,15 +50,18 @@ func GetUntrackedFiles() ([]string, error) {
 }
 
 // GetTrackedFiles returns a list of all git-tracked files
-func GetTrackedFiles(repoPath string) ([]string, error) {
-	result := sys.RunCommandInDir(repoPath, "git", "ls-files")
+func GetTrackedFiles(ctx context.Context, repoPath string) ([]string, error) {
+	// -z keeps paths with special characters unquoted
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "ls-files", "-z")
 	if result.ExitCode != 0 {
 		return nil, fmt.Errorf("failed to get tracked files: %s", result.Stderr)
 	}
 
-	if result.Stdout == "" {
-		return []string{}, nil
+	files := []string{}
+	for _, file := range strings.Split(result.Stdout, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
 	}
-
-	return strings.Split(result.Stdout, "\n"), nil
+	return files, nil
 }
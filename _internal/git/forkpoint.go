@@ -5,7 +5,6 @@ import (
 	"cli-go/_internal/sys"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 )
 
@@ -13,61 +12,27 @@ import (
 type ForkPointInfo struct {
 	BaseBranch string   `json:"base_branch"`
 	BaseCommit string   `json:"base_commit"`
+	BaseReason string   `json:"base_reason,omitempty"`
 	Files      []string `json:"files"`
 }
 
 // ForkPointDiffInfo holds information for diff operations
 type ForkPointDiffInfo struct {
-	BaseBranch string   `json:"base_branch"`
-	BaseCommit string   `json:"base_commit"`
-	Files      []string `json:"files"`
-	Selected   string   `json:"selected,omitempty"`
-	TempDir    string   `json:"temp_dir,omitempty"`
+	BaseBranch string       `json:"base_branch"`
+	BaseCommit string       `json:"base_commit"`
+	BaseReason string       `json:"base_reason,omitempty"`
+	Files      []string     `json:"files"`
+	Changes    []FileChange `json:"changes"`
+	Selected   string       `json:"selected,omitempty"`
 }
 
-// GetForkPointBranch detects the most likely branched-from remote branch
+// GetForkPointBranch returns the branch the current branch was forked from (see ResolveBaseBranch)
 func GetForkPointBranch() (string, error) {
-	// First check if we have any remote branches
-	result := sys.RunCommand("git", "for-each-ref", "--count=1", "refs/remotes/origin")
-	if result.ExitCode != 0 || result.Stdout == "" {
-		return "", fmt.Errorf("no remote branches found")
-	}
-
-	// Get current upstream to exclude it
-	currentUpstream := ""
-	upstreamResult := sys.RunCommand("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
-	if upstreamResult.ExitCode == 0 && upstreamResult.Stdout != "" {
-		currentUpstream = upstreamResult.Stdout
-	}
-
-	// Get recent remote branches (simplified approach)
-	result = sys.RunCommand("git", "for-each-ref", "--sort=-committerdate", "--count=5", "--format=%(refname:short)", "refs/remotes/origin")
-	if result.ExitCode != 0 {
-		return "", fmt.Errorf("failed to get remote branches: %s", result.Stderr)
-	}
-
-	lines := strings.Split(result.Stdout, "\n")
-
-	// Return the first valid remote branch (excluding current upstream)
-	for _, line := range lines {
-		if line == "" || line == "origin/HEAD" {
-			continue
-		}
-
-		// Skip current upstream
-		if currentUpstream != "" && line == currentUpstream {
-			continue
-		}
-
-		return line, nil
-	}
-
-	// If we only have one remote branch and it's the current upstream, use it anyway
-	if len(lines) > 0 && lines[0] != "" && lines[0] != "origin/HEAD" {
-		return lines[0], nil
+	base, err := ResolveBaseBranch("")
+	if err != nil {
+		return "", err
 	}
-
-	return "", fmt.Errorf("could not determine base branch")
+	return base.Branch, nil
 }
 
 // GetForkPointCommit gets the merge-base commit between HEAD and base branch
@@ -79,17 +44,13 @@ func GetForkPointCommit(baseBranch string) (string, error) {
 	return result.Stdout, nil
 }
 
-// GetChangedFilesSinceForkPoint returns files changed since fork point
-func GetChangedFilesSinceForkPoint() (*ForkPointInfo, error) {
-	baseBranch, err := GetForkPointBranch()
-	if err != nil {
-		return nil, err
-	}
-
-	baseCommit, err := GetForkPointCommit(baseBranch)
+// GetChangedFilesSinceForkPoint returns files changed since fork point; base overrides the detected base branch
+func GetChangedFilesSinceForkPoint(base string) (*ForkPointInfo, error) {
+	baseInfo, err := ResolveBaseBranch(base)
 	if err != nil {
 		return nil, err
 	}
+	baseBranch, baseCommit := baseInfo.Branch, baseInfo.ForkPoint
 
 	// Get changed files
 	result := sys.RunCommand("git", "diff", "--name-only", baseCommit)
@@ -113,148 +74,58 @@ func GetChangedFilesSinceForkPoint() (*ForkPointInfo, error) {
 	return &ForkPointInfo{
 		BaseBranch: baseBranch,
 		BaseCommit: baseCommit,
+		BaseReason: baseInfo.Reason,
 		Files:      files,
 	}, nil
 }
 
-// GetChangedFilesWithSelection returns files changed since fork point with interactive selection
-func GetChangedFilesWithSelection(selectedFile string, testMode bool) (*ForkPointDiffInfo, error) {
-	baseBranch, err := GetForkPointBranch()
+// GetChangedFilesWithSelection returns files changed since fork point that pass filter, with
+// interactive selection when no file is given; base overrides the detected base branch
+func GetChangedFilesWithSelection(selectedFile, base string, filter ChangeFilter, testMode bool) (*ForkPointDiffInfo, error) {
+	baseInfo, err := ResolveBaseBranch(base)
 	if err != nil {
 		return nil, err
 	}
 
-	baseCommit, err := GetForkPointCommit(baseBranch)
+	changes, err := GetChangesSince(baseInfo.ForkPoint)
 	if err != nil {
 		return nil, err
 	}
+	changes = FilterChanges(changes, filter)
 
-	// Get changed files
-	result := sys.RunCommand("git", "diff", "--name-only", baseCommit)
-	if result.ExitCode != 0 {
-		return nil, fmt.Errorf("failed to get changed files: %s", result.Stderr)
-	}
-
-	var files []string
-	if result.Stdout != "" {
-		fileList := strings.Split(result.Stdout, "\n")
-		// Remove empty strings
-		for _, file := range fileList {
-			if file != "" {
-				files = append(files, file)
-			}
-		}
+	info := &ForkPointDiffInfo{
+		BaseBranch: baseInfo.Branch,
+		BaseCommit: baseInfo.ForkPoint,
+		BaseReason: baseInfo.Reason,
+		Changes:    changes,
+		Selected:   selectedFile,
 	}
-
-	// If no files changed, return early
-	if len(files) == 0 {
-		return &ForkPointDiffInfo{
-			BaseBranch: baseBranch,
-			BaseCommit: baseCommit,
-			Files:      files,
-		}, nil
+	for _, change := range changes {
+		info.Files = append(info.Files, change.Path)
 	}
 
-	// If no file selected, use fzf for interactive selection
-	selected := selectedFile
-	if selected == "" {
-		if testMode {
-			// Test mode: pre-select first file
-			if len(files) > 0 {
-				selected = files[0]
-			} else {
-				// No files to select, return all files
-				return &ForkPointDiffInfo{
-					BaseBranch: baseBranch,
-					BaseCommit: baseCommit,
-					Files:      files,
-				}, nil
-			}
-		} else {
-			// Check if fzf is available
-			if _, err := exec.LookPath("fzf"); err == nil {
-				// Use fzf for selection with pre-selection for non-interactive environments
-				filesStr := strings.Join(files, "\n")
-				// Pre-select first file for non-interactive environments
-				preSelect := ""
-				if len(files) > 0 {
-					preSelect = fmt.Sprintf("--query=%s", files[0])
-				}
-				cmd := exec.Command("fzf", "--prompt=gaff> ", "--ansi", "--border", "--height=80%", preSelect)
-				cmd.Stdin = strings.NewReader(filesStr)
-				output, err := cmd.Output()
-				if err == nil && len(output) > 0 {
-					selected = strings.TrimSpace(string(output))
-				} else {
-					// fzf failed or was cancelled, return all files
-					return &ForkPointDiffInfo{
-						BaseBranch: baseBranch,
-						BaseCommit: baseCommit,
-						Files:      files,
-					}, nil
-				}
-			} else {
-				// Fallback: return all files
-				return &ForkPointDiffInfo{
-					BaseBranch: baseBranch,
-					BaseCommit: baseCommit,
-					Files:      files,
-				}, nil
-			}
-		}
+	// If no files changed or a file was given, there is nothing to select
+	if len(info.Files) == 0 || info.Selected != "" {
+		return info, nil
 	}
 
-	// If no file was selected (fzf failed or no fzf), return all files
-	if selected == "" {
-		return &ForkPointDiffInfo{
-			BaseBranch: baseBranch,
-			BaseCommit: baseCommit,
-			Files:      files,
-		}, nil
+	if testMode {
+		// Test mode: pre-select first file
+		info.Selected = info.Files[0]
+		return info, nil
 	}
 
-	// Get repo root
-	repoRoot, err := GetGitRoot()
-	if err != nil {
-		return nil, err
-	}
-
-	// Create temp directory for fork-point snapshots
-	tempDir := fmt.Sprintf("/tmp/gaff-%s-%s-%d",
-		strings.ReplaceAll(repoRoot, "/", "_"),
-		baseBranch,
-		os.Getpid())
-
-	// Create temp file for selected file
-	relPath := selected
-	if strings.HasPrefix(selected, repoRoot) {
-		relPath = strings.TrimPrefix(selected, repoRoot+"/")
-	}
-
-	tempFile := fmt.Sprintf("%s/%s", tempDir, relPath)
-
-	// Create temp directory
-	os.MkdirAll(filepath.Join(tempDir, filepath.Dir(relPath)), 0755)
-
-	// Create fork-point snapshot
-	snapshotResult := sys.RunCommand("git", "show", fmt.Sprintf("%s:%s", baseBranch, relPath))
-	if snapshotResult.ExitCode == 0 {
-		// Write snapshot to temp file
-		os.WriteFile(tempFile, []byte(snapshotResult.Stdout), 0644)
-	} else {
-		// Create empty file if snapshot doesn't exist
-		os.Create(tempFile)
+	// Use fzf for selection when available; without it (or when cancelled) all files are returned
+	if _, err := exec.LookPath("fzf"); err == nil {
+		cmd := exec.Command("fzf", "--prompt=gaff> ", "--ansi", "--border", "--height=80%")
+		cmd.Stdin = strings.NewReader(strings.Join(info.Files, "\n"))
+		cmd.Stderr = os.Stderr
+		if output, err := cmd.Output(); err == nil {
+			info.Selected = strings.TrimSpace(string(output))
+		}
 	}
 
-	// Editor launching logic moved to gaff tool
-
-	return &ForkPointDiffInfo{
-		BaseBranch: baseBranch,
-		BaseCommit: baseCommit,
-		Files:      files,
-		Selected:   selected,
-		TempDir:    tempDir,
-	}, nil
+	return info, nil
 }
 
 // Test comment
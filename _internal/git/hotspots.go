@@ -0,0 +1,193 @@
+package git
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileHotspot combines the change history of a file with its current size and complexity
+type FileHotspot struct {
+	Repository  string    `json:"repository"`
+	Path        string    `json:"path"`
+	Commits     int       `json:"commits"`
+	Authors     int       `json:"authors"`
+	Added       int       `json:"added"`
+	Deleted     int       `json:"deleted"`
+	Churn       int       `json:"churn"`
+	Lines       int       `json:"lines"`
+	Code        int       `json:"code"`
+	Complexity  int       `json:"complexity"`
+	Score       int       `json:"score"`
+	LastChanged time.Time `json:"last_changed"`
+
+	hashes  map[string]bool
+	authors map[string]bool
+}
+
+// DirHotspot is the rollup of the file hotspots in one directory
+type DirHotspot struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Files      int    `json:"files"`
+	Commits    int    `json:"commits"`
+	Authors    int    `json:"authors"`
+	Churn      int    `json:"churn"`
+	Lines      int    `json:"lines"`
+	Complexity int    `json:"complexity"`
+	Score      int    `json:"score"`
+}
+
+// GetFileChurn collects per-file change frequency, churn and authors since the given time for
+// the source files that still exist at HEAD, and measures their current size and complexity.
+// Renames are followed, so history under an old name counts for the current file.
+func GetFileChurn(ctx context.Context, repoPath string, since, until time.Time, exclude []string) ([]FileHotspot, error) {
+	commits, err := GetCommits(ctx, repoPath, LogOptions{Since: since, Until: until, NoMerges: true})
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := GetTrackedFiles(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool, len(tracked))
+	for _, file := range tracked {
+		exists[file] = true
+	}
+
+	// Commits are newest first, so a rename is seen before the older changes to the old path
+	renamed := make(map[string]string)
+	current := func(path string) string {
+		if to, ok := renamed[path]; ok {
+			return to
+		}
+		return path
+	}
+
+	byPath := make(map[string]*FileHotspot)
+	for _, commit := range commits {
+		for _, change := range commit.Files {
+			path := current(change.Path)
+			if change.OldPath != "" {
+				renamed[change.OldPath] = path
+			}
+			if !exists[path] {
+				continue
+			}
+
+			spot := byPath[path]
+			if spot == nil {
+				spot = &FileHotspot{Repository: repoPath, Path: path, hashes: make(map[string]bool), authors: make(map[string]bool)}
+				byPath[path] = spot
+			}
+			spot.hashes[commit.Hash] = true
+			spot.authors[commit.Author] = true
+			spot.Added += change.Added
+			spot.Deleted += change.Deleted
+			if commit.Date.After(spot.LastChanged) {
+				spot.LastChanged = commit.Date
+			}
+		}
+	}
+
+	var spots []FileHotspot
+	for path, spot := range byPath {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+		if !validExts[ext] || IsExcluded(path, exclude) {
+			continue
+		}
+		analysis, err := analyzeFile(filepath.Join(repoPath, path), ext)
+		if err != nil || analysis.Binary || analysis.Generated {
+			continue
+		}
+
+		spot.Commits = len(spot.hashes)
+		spot.Authors = len(spot.authors)
+		spot.Churn = spot.Added + spot.Deleted
+		spot.Lines = analysis.Lines
+		spot.Code = analysis.Counts.Code
+		spot.Complexity = analysis.Complexity
+		spots = append(spots, *spot)
+	}
+	sort.Slice(spots, func(i, j int) bool { return spots[i].Path < spots[j].Path })
+	return spots, nil
+}
+
+// RankHotspots scores files by change frequency times weight (code lines plus indentation
+// complexity), both relative to the highest in the set, on a 0-100 scale, and sorts them by score
+func RankHotspots(spots []FileHotspot) {
+	weight := func(spot FileHotspot) int { return spot.Code + spot.Complexity }
+	maxCommits, maxWeight := 0, 0
+	for _, spot := range spots {
+		maxCommits = max(maxCommits, spot.Commits)
+		maxWeight = max(maxWeight, weight(spot))
+	}
+
+	for i := range spots {
+		if maxCommits > 0 && maxWeight > 0 {
+			spots[i].Score = spots[i].Commits * weight(spots[i]) * 100 / (maxCommits * maxWeight)
+		}
+	}
+	sort.SliceStable(spots, func(i, j int) bool {
+		if spots[i].Score != spots[j].Score {
+			return spots[i].Score > spots[j].Score
+		}
+		if spots[i].Commits != spots[j].Commits {
+			return spots[i].Commits > spots[j].Commits
+		}
+		return spots[i].Churn > spots[j].Churn
+	})
+}
+
+// RollupHotspots sums ranked file hotspots per directory (see DirOf); commits and authors are counted once per directory
+func RollupHotspots(spots []FileHotspot, depth int) []DirHotspot {
+	type rollup struct {
+		dir     DirHotspot
+		hashes  map[string]bool
+		authors map[string]bool
+	}
+	byDir := make(map[string]*rollup)
+	var order []string
+
+	for _, spot := range spots {
+		key := spot.Repository + "\x00" + DirOf(spot.Path, depth)
+		r := byDir[key]
+		if r == nil {
+			r = &rollup{
+				dir:     DirHotspot{Repository: spot.Repository, Path: DirOf(spot.Path, depth)},
+				hashes:  make(map[string]bool),
+				authors: make(map[string]bool),
+			}
+			byDir[key] = r
+			order = append(order, key)
+		}
+		for hash := range spot.hashes {
+			r.hashes[hash] = true
+		}
+		for author := range spot.authors {
+			r.authors[author] = true
+		}
+		r.dir.Files++
+		r.dir.Churn += spot.Churn
+		r.dir.Lines += spot.Lines
+		r.dir.Complexity += spot.Complexity
+		r.dir.Score += spot.Score
+	}
+
+	dirs := make([]DirHotspot, 0, len(order))
+	for _, key := range order {
+		r := byDir[key]
+		r.dir.Commits = len(r.hashes)
+		r.dir.Authors = len(r.authors)
+		dirs = append(dirs, r.dir)
+	}
+	sort.SliceStable(dirs, func(i, j int) bool {
+		if dirs[i].Score != dirs[j].Score {
+			return dirs[i].Score > dirs[j].Score
+		}
+		return dirs[i].Churn > dirs[j].Churn
+	})
+	return dirs
+}
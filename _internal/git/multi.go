@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/config"
 )
@@ -61,20 +62,20 @@ func GetReposToProcess(singlePath string, mainFlag bool, allFlag bool, defaultMo
 	}
 }
 
-// RunAcrossRepos executes an operation across multiple repositories
-func RunAcrossRepos(repoPaths []string, operation func(string) (interface{}, error)) (map[string]interface{}, error) {
+// RunAcrossRepos executes an operation across multiple repositories in parallel;
+// failed repositories map to {"error": "..."}
+func RunAcrossRepos(ctx context.Context, repoPaths []string, operation func(ctx context.Context, repoPath string) (interface{}, error)) (map[string]interface{}, error) {
 	results := make(map[string]interface{})
 
-	for _, repoPath := range repoPaths {
-		result, err := operation(repoPath)
-		if err != nil {
-			// Log warning but continue with other repos
-			results[repoPath] = map[string]interface{}{
-				"error": err.Error(),
+	outcomes := RunParallel(ctx, repoPaths, PoolOptions{}, operation)
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			results[outcome.Repo] = map[string]interface{}{
+				"error": outcome.Error,
 			}
 			continue
 		}
-		results[repoPath] = result
+		results[outcome.Repo] = outcome.Value
 	}
 
 	return results, nil
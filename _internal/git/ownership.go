@@ -0,0 +1,135 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Owner is an author's share of the surviving lines
+type Owner struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Lines   int    `json:"lines"`
+	Percent int    `json:"percent"`
+}
+
+// DirOwnership is the ownership of one directory
+type DirOwnership struct {
+	Path      string  `json:"path"`
+	Files     int     `json:"files"`
+	Lines     int     `json:"lines"`
+	BusFactor int     `json:"bus_factor"`
+	Owners    []Owner `json:"owners"`
+}
+
+// RepoOwnership is the ownership report of one repository
+type RepoOwnership struct {
+	Repository  string         `json:"repository"`
+	Files       int            `json:"files"`
+	Lines       int            `json:"lines"`
+	BusFactor   int            `json:"bus_factor"`
+	Authors     []Owner        `json:"authors"`
+	Directories []DirOwnership `json:"directories"`
+	Warnings    []string       `json:"warnings,omitempty"`
+}
+
+// OwnershipOptions controls how AggregateOwnership groups files and when it warns
+type OwnershipOptions struct {
+	Depth     int // directory depth to aggregate at
+	Threshold int // warn when one author owns at least this % of a directory
+	MinLines  int // only warn for directories with at least this many lines
+}
+
+// ownerTally accumulates lines per author email
+type ownerTally struct {
+	files int
+	lines int
+	names map[string]string
+	count map[string]int
+}
+
+func newTally() *ownerTally {
+	return &ownerTally{names: make(map[string]string), count: make(map[string]int)}
+}
+
+func (t *ownerTally) add(authors []AuthorLines) {
+	for _, a := range authors {
+		t.names[a.Email] = a.Name
+		t.count[a.Email] += a.Lines
+		t.lines += a.Lines
+	}
+}
+
+// owners returns the authors sorted by lines
+func (t *ownerTally) owners() []Owner {
+	var owners []Owner
+	for email, lines := range t.count {
+		owner := Owner{Name: t.names[email], Email: email, Lines: lines}
+		if t.lines > 0 {
+			owner.Percent = lines * 100 / t.lines
+		}
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Lines != owners[j].Lines {
+			return owners[i].Lines > owners[j].Lines
+		}
+		return owners[i].Email < owners[j].Email
+	})
+	return owners
+}
+
+// busFactor is the smallest number of authors that together own more than half of the lines
+func busFactor(owners []Owner, total int) int {
+	sum := 0
+	for i, owner := range owners {
+		sum += owner.Lines
+		if sum*2 > total {
+			return i + 1
+		}
+	}
+	return len(owners)
+}
+
+// AggregateOwnership sums the blamed lines of files (by repository-relative path) per author
+// for the repository and per directory, with bus-factor warnings
+func AggregateOwnership(repoPath string, files map[string][]AuthorLines, options OwnershipOptions) RepoOwnership {
+	report := RepoOwnership{Repository: repoPath}
+
+	total := newTally()
+	dirs := make(map[string]*ownerTally)
+	for path, authors := range files {
+		dir := DirOf(path, options.Depth)
+		if dirs[dir] == nil {
+			dirs[dir] = newTally()
+		}
+		dirs[dir].files++
+		dirs[dir].add(authors)
+		total.files++
+		total.add(authors)
+	}
+
+	report.Files = total.files
+	report.Lines = total.lines
+	report.Authors = total.owners()
+	report.BusFactor = busFactor(report.Authors, total.lines)
+
+	for path, tally := range dirs {
+		owners := tally.owners()
+		dir := DirOwnership{Path: path, Files: tally.files, Lines: tally.lines, Owners: owners, BusFactor: busFactor(owners, tally.lines)}
+		report.Directories = append(report.Directories, dir)
+
+		if len(owners) > 0 && tally.lines >= options.MinLines && owners[0].Percent >= options.Threshold {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %d%% of %d lines by %s", path, owners[0].Percent, tally.lines, owners[0].Name))
+		}
+	}
+	sort.Slice(report.Directories, func(i, j int) bool {
+		return report.Directories[i].Path < report.Directories[j].Path
+	})
+	sort.Strings(report.Warnings)
+	if report.BusFactor == 1 && len(report.Authors) > 1 {
+		report.Warnings = append([]string{fmt.Sprintf("repository: %s owns more than half of all lines (bus factor 1)", report.Authors[0].Name)}, report.Warnings...)
+	}
+
+	return report
+}
@@ -0,0 +1,184 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"cli-go/_internal/config"
+
+	"golang.org/x/term"
+)
+
+// RepoResult is the outcome of an operation on one repository
+type RepoResult[T any] struct {
+	Repo     string        `json:"repo"`
+	Value    T             `json:"value"`
+	Err      error         `json:"-"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// PoolOptions controls RunParallel; zero values fall back to the parallel section of config.yml
+type PoolOptions struct {
+	Workers int
+	Timeout time.Duration // per item, i.e. per repository unless op works on something else (like files)
+	Label   string        // progress label, e.g. "Fetching commits"
+	Quiet   bool          // no progress on stderr
+}
+
+// RunParallel runs op for every repo on a bounded worker pool and returns the results in input order.
+// Each call gets its own timeout through its ctx, which kills the git commands it runs, and is
+// reported as timed out when the deadline passes. Progress is shown on stderr when it is a terminal.
+func RunParallel[T any](ctx context.Context, repos []string, opts PoolOptions, op func(ctx context.Context, repo string) (T, error)) []RepoResult[T] {
+	opts = withPoolDefaults(opts)
+	results := make([]RepoResult[T], len(repos))
+	progress := newProgress(opts, len(repos))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(opts.Workers, len(repos)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runOne(ctx, repos[i], opts.Timeout, op)
+				progress.done(results[i].Repo, results[i].Err)
+			}
+		}()
+	}
+
+	for i := range repos {
+		if ctx.Err() != nil {
+			results[i] = RepoResult[T]{Repo: repos[i], Err: ctx.Err(), Error: ctx.Err().Error()}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	progress.finish()
+
+	return results
+}
+
+// runOne runs op with a per-repo timeout, recovering panics as errors
+func runOne[T any](ctx context.Context, repo string, timeout time.Duration, op func(ctx context.Context, repo string) (T, error)) (result RepoResult[T]) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	result.Repo = repo
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = fmt.Errorf("panic: %v", r)
+		}
+		if result.Err != nil && ctx.Err() == context.DeadlineExceeded {
+			result.Err = fmt.Errorf("timed out after %s", timeout)
+		}
+		if result.Err != nil {
+			result.Error = result.Err.Error()
+		}
+		result.Duration = time.Since(start)
+	}()
+
+	result.Value, result.Err = op(ctx, repo)
+	return result
+}
+
+// Failures returns the results that failed
+func Failures[T any](results []RepoResult[T]) []RepoResult[T] {
+	var failed []RepoResult[T]
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// ReportFailures prints a partial-failure summary on stderr and returns the number of failed repos
+func ReportFailures[T any](results []RepoResult[T]) int {
+	failed := Failures(results)
+	if len(failed) == 0 {
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: %d of %d repositories failed:\n", len(failed), len(results))
+	for _, r := range failed {
+		fmt.Fprintf(os.Stderr, "  • %s: %v\n", r.Repo, r.Err)
+	}
+	return len(failed)
+}
+
+// withPoolDefaults fills unset options from config.yml (parallel.workers, parallel.repo_timeout_seconds)
+func withPoolDefaults(opts PoolOptions) PoolOptions {
+	if opts.Workers > 0 && opts.Timeout > 0 {
+		return opts
+	}
+
+	workers, timeout := 4, 120
+	if cfg, err := config.LoadConfig(); err == nil {
+		workers, timeout = cfg.Parallel.Workers, cfg.Parallel.RepoTimeoutSeconds
+	}
+	// Without a worker nothing reads the jobs channel, so a bad config value must not get through
+	if opts.Workers <= 0 {
+		opts.Workers = max(workers, 1)
+	}
+	if opts.Timeout <= 0 {
+		if timeout <= 0 {
+			timeout = 120
+		}
+		opts.Timeout = time.Duration(timeout) * time.Second
+	}
+	return opts
+}
+
+// progress prints a single updating status line on stderr
+type progress struct {
+	mu      sync.Mutex
+	enabled bool
+	label   string
+	total   int
+	count   int
+	failed  int
+}
+
+func newProgress(opts PoolOptions, total int) *progress {
+	label := opts.Label
+	if label == "" {
+		label = "Processing repositories"
+	}
+	enabled := !opts.Quiet && total > 1 && term.IsTerminal(int(os.Stderr.Fd()))
+	return &progress{enabled: enabled, label: label, total: total}
+}
+
+// done records a finished repository and redraws the status line
+func (p *progress) done(repo string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.count++
+	if err != nil {
+		p.failed++
+	}
+	if !p.enabled {
+		return
+	}
+
+	status := fmt.Sprintf("%s [%d/%d] %s", p.label, p.count, p.total, filepath.Base(repo))
+	if p.failed > 0 {
+		status += fmt.Sprintf(" (%d failed)", p.failed)
+	}
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", status)
+}
+
+// finish clears the status line
+func (p *progress) finish() {
+	if p.enabled && p.count > 0 {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
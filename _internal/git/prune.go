@@ -0,0 +1,156 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"cli-go/_internal/sys"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reasons a branch is suggested for pruning
+const (
+	PruneMerged       = "merged"
+	PruneMergedPR     = "PR merged"
+	PruneUpstreamGone = "upstream gone"
+	PruneInactive     = "inactive"
+)
+
+// PruneCandidate is a branch that is merged, lost its upstream or has been inactive
+type PruneCandidate struct {
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch"`
+	Commit     string    `json:"commit"` // tip, to restore a deleted branch
+	Remote     bool      `json:"remote"` // a branch on origin, Branch is without the origin/ prefix
+	Reasons    []string  `json:"reasons"`
+	LastCommit time.Time `json:"last_commit"`
+	Author     string    `json:"author"`
+	Subject    string    `json:"subject"`
+}
+
+// PruneOptions selects which branches FindPruneCandidates reports
+type PruneOptions struct {
+	InactiveDays int             // 0 disables the inactivity check
+	Remote       bool            // also check branches on origin
+	MergedPRs    map[string]bool // head branches of merged PRs, to catch squash and rebase merges
+}
+
+// ProtectedBranches returns the branches of a repository that are never pruned: the long-lived
+// branches, the main branch and the current branch
+func ProtectedBranches(repoPath string) map[string]bool {
+	protected := map[string]bool{MainBranchAt(repoPath): true}
+	for _, branch := range longLivedBranches {
+		protected[branch] = true
+	}
+	if current, err := GetCurrentBranch(repoPath); err == nil && current != "HEAD" {
+		protected[current] = true
+	}
+	return protected
+}
+
+// FindPruneCandidates returns the local (and with Remote, origin) branches of a repository that are
+// merged into the main branch, have lost their upstream or have not been committed to for InactiveDays
+func FindPruneCandidates(ctx context.Context, repoPath string, options PruneOptions) ([]PruneCandidate, error) {
+	main := MainBranchAt(repoPath)
+	base := "origin/" + main
+	if sys.RunCommandInDir(repoPath, "git", "rev-parse", "--verify", "--quiet", base).ExitCode != 0 {
+		base = main
+	}
+	protected := ProtectedBranches(repoPath)
+	cutoff := time.Now().AddDate(0, 0, -options.InactiveDays)
+
+	namespaces := []string{"refs/heads"}
+	if options.Remote {
+		namespaces = append(namespaces, "refs/remotes/origin")
+	}
+
+	var candidates []PruneCandidate
+	for _, namespace := range namespaces {
+		remote := namespace != "refs/heads"
+		merged, err := mergedBranches(ctx, repoPath, base, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		result := sys.RunCommandInDirContext(ctx, repoPath, "git", "for-each-ref",
+			"--format=%(refname)%00%(committerdate:unix)%00%(authorname)%00%(subject)%00%(upstream:track)%00%(objectname)", namespace)
+		if result.ExitCode != 0 {
+			return nil, fmt.Errorf("failed to list branches: %s", result.Stderr)
+		}
+
+		for _, line := range strings.Split(result.Stdout, "\n") {
+			fields := strings.SplitN(line, "\x00", 6)
+			if len(fields) != 6 {
+				continue
+			}
+			name := strings.TrimPrefix(fields[0], namespace+"/")
+			if name == "HEAD" || protected[name] {
+				continue
+			}
+
+			candidate := PruneCandidate{Repository: repoPath, Branch: name, Commit: fields[5], Remote: remote, Author: fields[2], Subject: fields[3]}
+			if seconds, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				candidate.LastCommit = time.Unix(seconds, 0)
+			}
+			if merged[fields[0]] {
+				candidate.Reasons = append(candidate.Reasons, PruneMerged)
+			} else if options.MergedPRs[name] {
+				candidate.Reasons = append(candidate.Reasons, PruneMergedPR)
+			}
+			if fields[4] == "[gone]" {
+				candidate.Reasons = append(candidate.Reasons, PruneUpstreamGone)
+			}
+			if options.InactiveDays > 0 && candidate.LastCommit.Before(cutoff) {
+				candidate.Reasons = append(candidate.Reasons, PruneInactive)
+			}
+			if len(candidate.Reasons) > 0 {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastCommit.Before(candidates[j].LastCommit)
+	})
+	return candidates, nil
+}
+
+// mergedBranches returns the full ref names in namespace that are reachable from base
+func mergedBranches(ctx context.Context, repoPath, base, namespace string) (map[string]bool, error) {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "for-each-ref", "--merged", base, "--format=%(refname)", namespace)
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list branches merged into %s: %s", base, result.Stderr)
+	}
+	merged := make(map[string]bool)
+	for _, ref := range strings.Split(result.Stdout, "\n") {
+		if ref != "" {
+			merged[ref] = true
+		}
+	}
+	return merged, nil
+}
+
+// InactiveOnly reports whether the branch is only stale: its work is not merged anywhere
+func (c PruneCandidate) InactiveOnly() bool {
+	return len(c.Reasons) == 1 && c.Reasons[0] == PruneInactive
+}
+
+// DeletePruneCandidate deletes a local branch or the branch on origin. Merged, PR merged and gone
+// branches are force-deleted, since squash merges and gone upstreams are not merged for git;
+// inactive-only local branches use a plain delete, which git refuses while their work is unmerged.
+func DeletePruneCandidate(candidate PruneCandidate) error {
+	args := []string{"branch", "--delete", "--force", candidate.Branch}
+	if candidate.InactiveOnly() {
+		args = []string{"branch", "--delete", candidate.Branch}
+	}
+	if candidate.Remote {
+		args = []string{"push", "origin", "--delete", candidate.Branch}
+	}
+	result := sys.RunCommandInDir(candidate.Repository, "git", args...)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to delete %s: %s", candidate.Branch, result.Stderr)
+	}
+	return nil
+}
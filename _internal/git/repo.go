@@ -2,8 +2,10 @@ package git
 
 import (
 	"fmt"
+	"cli-go/_internal/config"
 	"cli-go/_internal/sys"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -41,9 +43,9 @@ func GetGitRoot() (string, error) {
 	return strings.TrimSpace(result.Stdout), nil
 }
 
-// GetCurrentBranch returns the current git branch
-func GetCurrentBranch() (string, error) {
-	result := sys.RunCommand("git", "rev-parse", "--abbrev-ref", "HEAD")
+// GetCurrentBranch returns the current git branch of a repository ("" for the current directory)
+func GetCurrentBranch(repoPath string) (string, error) {
+	result := sys.RunCommandInDir(repoPath, "git", "rev-parse", "--abbrev-ref", "HEAD")
 	if result.ExitCode != 0 {
 		return "", fmt.Errorf("failed to get current branch: %s", result.Stderr)
 	}
@@ -78,7 +80,7 @@ func GetRepoInfo() (*RepoInfo, error) {
 		return nil, err
 	}
 
-	branch, err := GetCurrentBranch()
+	branch, err := GetCurrentBranch(root)
 	if err != nil {
 		return nil, err
 	}
@@ -89,3 +91,34 @@ func GetRepoInfo() (*RepoInfo, error) {
 		Branch: branch,
 	}, nil
 }
+
+// GetUserEmail returns the configured git user.email for a repository
+func GetUserEmail(repoPath string) (string, error) {
+	result := sys.RunCommandInDir(repoPath, "git", "config", "user.email")
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("git user.email not configured in %s", repoPath)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// GetRepoSlug returns owner/repo of a repository, from config.yml when configured, else from the GitHub origin url
+func GetRepoSlug(repoPath string) (string, error) {
+	if cfg, err := config.LoadConfig(); err == nil {
+		for _, repo := range cfg.Repositories {
+			if repo.Owner != "" && repo.Repo != "" && filepath.Clean(repo.Path) == filepath.Clean(repoPath) {
+				return repo.Owner + "/" + repo.Repo, nil
+			}
+		}
+	}
+
+	url, err := GetRemoteURL(repoPath)
+	if err != nil {
+		return "", err
+	}
+	_, path, found := strings.Cut(url, "github.com/")
+	parts := strings.Split(path, "/")
+	if !found || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("origin is not a GitHub repository: %s", url)
+	}
+	return parts[0] + "/" + parts[1], nil
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotRoot is the per-user directory WriteSnapshot writes to; every run empties it
+func snapshotRoot() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gaff-%d", os.Getuid()))
+}
+
+// WriteSnapshot writes the version of basePath at commit to relPath in a new directory under the
+// snapshot root and returns the directory and file; a path that doesn't exist at commit gives an empty file
+func WriteSnapshot(repoRoot, commit, basePath, relPath string) (string, string, error) {
+	branch, err := GetCurrentBranch("")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get current branch: %v", err)
+	}
+
+	if err := os.MkdirAll(snapshotRoot(), 0700); err != nil {
+		return "", "", fmt.Errorf("failed to create temp dir: %v", err)
+	}
+	dir, err := os.MkdirTemp(snapshotRoot(), fmt.Sprintf("%s-%s-", filepath.Base(repoRoot), strings.ReplaceAll(branch, "/", "-")))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp dir: %v", err)
+	}
+
+	snapshot := filepath.Join(dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(snapshot), 0755); err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("failed to create temp dir: %v", err)
+	}
+
+	content, err := exec.Command("git", "-C", repoRoot, "show", fmt.Sprintf("%s:%s", commit, basePath)).Output()
+	if err != nil {
+		content = []byte{}
+	}
+	if err := os.WriteFile(snapshot, content, 0644); err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("failed to write temp file: %v", err)
+	}
+	return dir, snapshot, nil
+}
+
+// RemoveSnapshots removes the snapshots of earlier runs, which their editors have loaded by now
+func RemoveSnapshots() {
+	os.RemoveAll(snapshotRoot())
+}
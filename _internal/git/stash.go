@@ -3,6 +3,7 @@ package git
 import (
 	"fmt"
 	"cli-go/_internal/sys"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -27,28 +28,7 @@ func CreateSmartStash() (*StashInfo, error) {
 		return nil, err
 	}
 
-	// Combine and limit to 3 files
-	allFiles := append(modified, untracked...)
-	if len(allFiles) > 3 {
-		allFiles = allFiles[:3]
-	}
-
-	// Create message with timestamp and file names
-	timestamp := time.Now().Format("15:04")
-	var message string
-
-	if len(allFiles) > 0 {
-		// Get basenames of files
-		basenames := make([]string, len(allFiles))
-		for i, file := range allFiles {
-			parts := strings.Split(file, "/")
-			basenames[i] = parts[len(parts)-1]
-		}
-		filesStr := strings.Join(basenames, " | ")
-		message = fmt.Sprintf("[%s] %s", timestamp, filesStr)
-	} else {
-		message = fmt.Sprintf("[%s]", timestamp)
-	}
+	message := smartStashMessage(append(modified, untracked...))
 
 	// Create the stash
 	result := sys.RunCommand("git", "stash", "push", "-m", message)
@@ -62,6 +42,28 @@ func CreateSmartStash() (*StashInfo, error) {
 	}, nil
 }
 
+// smartStashMessage builds a "[HH:MM] file | file | file" message from up to 3 file names
+func smartStashMessage(files []string) string {
+	// Limit to 3 files
+	if len(files) > 3 {
+		files = files[:3]
+	}
+
+	// Create message with timestamp and file names
+	timestamp := time.Now().Format("15:04")
+	if len(files) == 0 {
+		return fmt.Sprintf("[%s]", timestamp)
+	}
+
+	// Get basenames of files
+	basenames := make([]string, len(files))
+	for i, file := range files {
+		parts := strings.Split(file, "/")
+		basenames[i] = parts[len(parts)-1]
+	}
+	return fmt.Sprintf("[%s] %s", timestamp, strings.Join(basenames, " | "))
+}
+
 // PopStash pops the most recent stash
 func PopStash() (*StashInfo, error) {
 	result := sys.RunCommand("git", "stash", "pop")
@@ -88,16 +90,185 @@ func PopStash() (*StashInfo, error) {
 	}, nil
 }
 
-// ListStashes returns a list of available stashes
-func ListStashes() ([]string, error) {
-	result := sys.RunCommand("git", "stash", "list")
+// Stash is an entry of the stash list with the files it contains
+type Stash struct {
+	Index   int          `json:"index"`
+	Ref     string       `json:"ref"`
+	Hash    string       `json:"hash"`
+	Branch  string       `json:"branch"`
+	Message string       `json:"message"`
+	Date    time.Time    `json:"date"`
+	Files   []FileChange `json:"files"`
+	Added   int          `json:"added"`
+	Deleted int          `json:"deleted"`
+}
+
+// StashOptions controls what PushStash stashes
+type StashOptions struct {
+	Message   string   // default: "[HH:MM] file | file" as with CreateSmartStash
+	Untracked bool     // include untracked files
+	Paths     []string // only stash these paths
+}
+
+// ListStashes returns the stashes, newest first, with their branch, date and changed files
+func ListStashes() ([]Stash, error) {
+	result := sys.RunCommand("git", "stash", "list", "--format=%gd%x00%ct%x00%H%x00%gs")
 	if result.ExitCode != 0 {
 		return nil, fmt.Errorf("failed to list stashes: %s", result.Stderr)
 	}
 
+	stashes := []Stash{}
 	if result.Stdout == "" {
-		return []string{}, nil
+		return stashes, nil
+	}
+	for i, line := range strings.Split(result.Stdout, "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		stash := Stash{Index: i, Ref: fields[0], Hash: fields[2]}
+		if seconds, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			stash.Date = time.Unix(seconds, 0)
+		}
+		stash.Branch, stash.Message = parseStashSubject(fields[3])
+
+		// Untracked files are stored in a separate commit, --include-untracked shows them too
+		stash.Files, _ = readChanges([]string{"stash", "show", "--include-untracked"}, stash.Ref)
+		for _, file := range stash.Files {
+			stash.Added += file.Added
+			stash.Deleted += file.Deleted
+		}
+		stashes = append(stashes, stash)
+	}
+	return stashes, nil
+}
+
+// parseStashSubject splits "On <branch>: <message>" or "WIP on <branch>: <hash> <subject>"
+func parseStashSubject(subject string) (string, string) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(subject, "WIP on "), "On ")
+	if i := strings.Index(rest, ": "); i >= 0 {
+		return rest[:i], rest[i+2:]
+	}
+	return "", subject
+}
+
+// FindStash resolves a stash by index (2 or stash@{2}) or by a case-insensitive search in the
+// stash messages; an empty query is the latest stash, and a search must match exactly one stash
+func FindStash(query string) (*Stash, error) {
+	stashes, err := ListStashes()
+	if err != nil {
+		return nil, err
+	}
+	if len(stashes) == 0 {
+		return nil, fmt.Errorf("no stashes")
+	}
+	if query == "" {
+		return &stashes[0], nil
+	}
+
+	if index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(query, "stash@{"), "}")); err == nil {
+		for i := range stashes {
+			if stashes[i].Index == index {
+				return &stashes[i], nil
+			}
+		}
+		return nil, fmt.Errorf("stash %s not found (%d stashes)", query, len(stashes))
+	}
+
+	var matches []*Stash
+	for i := range stashes {
+		if strings.Contains(strings.ToLower(stashes[i].Message), strings.ToLower(query)) {
+			matches = append(matches, &stashes[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no stash message matches %q", query)
+	case 1:
+		return matches[0], nil
+	}
+	refs := make([]string, len(matches))
+	for i, match := range matches {
+		refs[i] = fmt.Sprintf("%s (%s)", match.Ref, match.Message)
+	}
+	return nil, fmt.Errorf("%q matches %d stashes, use an index: %s", query, len(matches), strings.Join(refs, ", "))
+}
+
+// PushStash stashes the working tree changes, optionally with untracked files or only some paths
+func PushStash(options StashOptions) (*StashInfo, error) {
+	message := options.Message
+	if message == "" {
+		files := options.Paths
+		if len(files) == 0 {
+			modified, err := GetModifiedFiles()
+			if err != nil {
+				return nil, err
+			}
+			files = modified
+			if options.Untracked {
+				untracked, err := GetUntrackedFiles()
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, untracked...)
+			}
+		}
+		message = smartStashMessage(files)
+	}
+
+	args := []string{"stash", "push", "-m", message}
+	if options.Untracked {
+		args = append(args, "--include-untracked")
+	}
+	if len(options.Paths) > 0 {
+		args = append(append(args, "--"), options.Paths...)
+	}
+	result := sys.RunCommand("git", args...)
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to create stash: %s", result.Stderr)
+	}
+	if strings.Contains(result.Stdout, "No local changes to save") {
+		return &StashInfo{Message: "no local changes to save", Stashed: false}, nil
+	}
+	return &StashInfo{Message: message, Stashed: true}, nil
+}
+
+// ApplyStash applies a stash to the working tree; pop also drops it when it applied cleanly
+func ApplyStash(ref string, pop bool) error {
+	action := "apply"
+	if pop {
+		action = "pop"
+	}
+	result := sys.RunCommand("git", "stash", action, ref)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to %s %s: %s", action, ref, strings.TrimSpace(result.Stderr+"\n"+result.Stdout))
+	}
+	return nil
+}
+
+// DropStash removes a stash; it can be restored with git stash store <hash> until garbage collected
+func DropStash(ref string) error {
+	result := sys.RunCommand("git", "stash", "drop", ref)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to drop %s: %s", ref, result.Stderr)
 	}
+	return nil
+}
 
-	return strings.Split(result.Stdout, "\n"), nil
+// StashToBranch creates a branch at the commit the stash was made on, applies the stash there and drops it
+func StashToBranch(ref, branch string) error {
+	result := sys.RunCommand("git", "stash", "branch", branch, ref)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to create branch %s from %s: %s", branch, ref, strings.TrimSpace(result.Stderr+"\n"+result.Stdout))
+	}
+	return nil
+}
+
+// GetStashDiff returns the patch of a stash, including its untracked files
+func GetStashDiff(ref string) (string, error) {
+	result := sys.RunCommand("git", "stash", "show", "-p", "-M", "--no-color", "--include-untracked", ref)
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("failed to show %s: %s", ref, result.Stderr)
+	}
+	return result.Stdout, nil
 }
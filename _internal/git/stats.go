@@ -1,10 +1,11 @@
 package git
 
 import (
+	"context"
 	"bufio"
 	"fmt"
+	"cli-go/_internal/config"
 	"cli-go/_internal/sys"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
@@ -39,19 +40,29 @@ type FileStats struct {
 	Lines     int    `json:"lines"`
 	Percent   int    `json:"percent"`
 	AvgLines  int    `json:"avg_lines"`
+	LineCounts
 }
 
 // RepoStats represents statistics for a single repository
 type RepoStats struct {
-	Extensions   []FileStats `json:"extensions"`
-	TotalFiles   int         `json:"total_files"`
-	TotalLines   int         `json:"total_lines"`
-	Branches     int         `json:"branches"`
-	Commits      int         `json:"commits"`
-	Authors      int         `json:"authors"`
-	LinesAdded   int         `json:"linesAdded"`
-	LinesDeleted int         `json:"linesDeleted"`
-	Tickets      []string    `json:"tickets"`
+	Repository   string          `json:"repository,omitempty"`
+	Extensions   []FileStats     `json:"extensions"`
+	Languages    []LanguageStats `json:"languages"`
+	TotalFiles   int             `json:"total_files"`
+	TotalLines   int             `json:"total_lines"`
+	Branches     int             `json:"branches"`
+	Commits      int             `json:"commits"`
+	Authors      int             `json:"authors"`
+	LinesAdded   int             `json:"linesAdded"`
+	LinesDeleted int             `json:"linesDeleted"`
+	Tickets      []string        `json:"tickets"`
+
+	// Generated and minified files are listed here and left out of the totals
+	Generated      []string `json:"generated,omitempty"`
+	GeneratedLines int      `json:"generated_lines,omitempty"`
+	Excluded       int      `json:"excluded,omitempty"`
+
+	authorNames []string // for de-duplicating authors across repositories
 }
 
 // CommitStats represents commit statistics
@@ -72,111 +83,95 @@ type DayStats struct {
 	Authors      []string `json:"authors"`
 }
 
-// findSourceFiles finds all source files in the repository using native Go
-func findSourceFiles(repoPath string) ([]string, error) {
-	var files []string
-	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if d.IsDir() {
-			return nil
-		}
+// StatsOptions controls which tracked files GetRepoStats counts
+type StatsOptions struct {
+	Exclude          []string // patterns matched by IsExcluded
+	IncludeGenerated bool     // count generated and minified files in the totals
+}
 
-		ext := strings.TrimPrefix(filepath.Ext(path), ".")
-		if validExts[ext] {
-			files = append(files, path)
-		}
-		return nil
-	})
-	return files, err
+// GetRepoStats calculates statistics for a repository, excluding the paths in stats.exclude
+func GetRepoStats(ctx context.Context, repoPath string) (*RepoStats, error) {
+	opts := StatsOptions{Exclude: config.DefaultStatsExclude}
+	if cfg, err := config.LoadConfig(); err == nil {
+		opts.Exclude = cfg.Stats.Exclude
+	}
+	return GetRepoStatsWithOptions(ctx, repoPath, opts)
 }
 
-// GetRepoStats calculates statistics for a repository
-func GetRepoStats(repoPath string) (*RepoStats, error) {
-	// Get all files in the repository using native Go
-	filePaths, err := findSourceFiles(repoPath)
+// GetRepoStatsWithOptions calculates statistics for the git-tracked source files of a repository
+func GetRepoStatsWithOptions(ctx context.Context, repoPath string, opts StatsOptions) (*RepoStats, error) {
+	// Tracked files only, so ignored build output and dependencies never count
+	files, err := GetTrackedFiles(ctx, repoPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find files: %v", err)
+		return nil, err
 	}
 
-	// Convert to string for compatibility with existing code
-	files := strings.Join(filePaths, "\n")
-
+	stats := &RepoStats{Repository: repoPath}
 	extStats := make(map[string]*FileStats)
-	totalFiles := 0
-	totalLines := 0
 
-	// Process each file
-	fileLines := strings.Split(files, "\n")
-	for _, file := range fileLines {
-		if file == "" {
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file), "."))
+		if !validExts[ext] {
 			continue
 		}
-
-		ext := strings.ToLower(filepath.Ext(file))
-		if ext != "" {
-			ext = ext[1:] // Remove the dot
+		if IsExcluded(file, opts.Exclude) {
+			stats.Excluded++
+			continue
 		}
 
-		if !validExts[ext] {
+		analysis, err := analyzeFile(filepath.Join(repoPath, file), ext)
+		if err != nil || analysis.Binary {
+			continue // Skip files that can't be read (e.g. deleted in the worktree)
+		}
+		if analysis.Generated && !opts.IncludeGenerated {
+			stats.Generated = append(stats.Generated, file)
+			stats.GeneratedLines += analysis.Lines
 			continue
 		}
 
 		if extStats[ext] == nil {
 			extStats[ext] = &FileStats{Extension: ext}
 		}
-
-		// Count lines in file
-		// file already contains the full path from find command
-		fullPath := file
-		lines, err := CountLinesInFile(fullPath)
-		if err != nil {
-			continue // Skip files that can't be read
-		}
-
 		extStats[ext].Files++
-		extStats[ext].Lines += lines
-		totalFiles++
-		totalLines += lines
+		extStats[ext].Lines += analysis.Lines
+		extStats[ext].Add(analysis.Counts)
+		stats.TotalFiles++
+		stats.TotalLines += analysis.Lines
 	}
 
 	// Convert to slice and calculate percentages
-	var extensions []FileStats
-	for _, stats := range extStats {
-		if totalLines > 0 {
-			stats.Percent = int((float64(stats.Lines) / float64(totalLines)) * 100)
+	for _, ext := range extStats {
+		if stats.TotalLines > 0 {
+			ext.Percent = int((float64(ext.Lines) / float64(stats.TotalLines)) * 100)
 		}
-		if stats.Files > 0 {
-			stats.AvgLines = stats.Lines / stats.Files
+		if ext.Files > 0 {
+			ext.AvgLines = ext.Lines / ext.Files
 		}
-		extensions = append(extensions, *stats)
+		stats.Extensions = append(stats.Extensions, *ext)
 	}
 
 	// Sort by lines (descending)
-	sort.Slice(extensions, func(i, j int) bool {
-		return extensions[i].Lines > extensions[j].Lines
+	sort.Slice(stats.Extensions, func(i, j int) bool {
+		return stats.Extensions[i].Lines > stats.Extensions[j].Lines
 	})
+	stats.Languages = GroupLanguages(stats.Extensions)
 
 	// Get additional stats
-	branches := GetBranchCount(repoPath)
-	commits, err := GetCommitCount(repoPath)
+	stats.Branches = GetBranchCount(ctx, repoPath)
+	stats.Commits, err = GetCommitCount(ctx, repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commit count: %v", err)
 	}
-	authors, err := GetAuthorCount(repoPath)
+	stats.authorNames, err = GetAuthors(ctx, repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get author count: %v", err)
 	}
+	stats.Authors = len(stats.authorNames)
 
-	return &RepoStats{
-		Extensions: extensions,
-		TotalFiles: totalFiles,
-		TotalLines: totalLines,
-		Branches:   branches,
-		Commits:    commits,
-		Authors:    authors,
-	}, nil
+	return stats, nil
 }
 
 // CalculateCommitStats calculates statistics from commits
@@ -252,8 +247,8 @@ func CountLinesInFile(filePath string) (int, error) {
 }
 
 // GetCommitCount returns the number of commits in the repository
-func GetCommitCount(repoPath string) (int, error) {
-	result := sys.RunCommand("git", "-C", repoPath, "rev-list", "--count", "HEAD")
+func GetCommitCount(ctx context.Context, repoPath string) (int, error) {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "rev-list", "--count", "HEAD")
 	if result.Error != nil {
 		return 0, result.Error
 	}
@@ -265,22 +260,27 @@ func GetCommitCount(repoPath string) (int, error) {
 }
 
 // GetAuthorCount returns the number of unique authors in the repository
-func GetAuthorCount(repoPath string) (int, error) {
-	// Get all authors
-	result := sys.RunCommand("git", "-C", repoPath, "log", "--pretty=format:%an")
+func GetAuthorCount(ctx context.Context, repoPath string) (int, error) {
+	authors, err := GetAuthors(ctx, repoPath)
+	return len(authors), err
+}
+
+// GetAuthors returns the unique author names of the repository
+func GetAuthors(ctx context.Context, repoPath string) ([]string, error) {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "log", "--pretty=format:%an")
 	if result.Error != nil {
-		return 0, result.Error
+		return nil, result.Error
 	}
 
-	// Count unique authors
-	authors := strings.Split(result.Stdout, "\n")
-	uniqueAuthors := make(map[string]bool)
-	for _, author := range authors {
+	var authors []string
+	seen := make(map[string]bool)
+	for _, author := range strings.Split(result.Stdout, "\n") {
 		author = strings.TrimSpace(author)
-		if author != "" {
-			uniqueAuthors[author] = true
+		if author != "" && !seen[author] {
+			seen[author] = true
+			authors = append(authors, author)
 		}
 	}
 
-	return len(uniqueAuthors), nil
+	return authors, nil
 }
@@ -0,0 +1,132 @@
+package git
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LanguageStats represents statistics for a language spanning one or more extensions
+type LanguageStats struct {
+	Language   string   `json:"language"`
+	Extensions []string `json:"extensions"`
+	Files      int      `json:"files"`
+	Lines      int      `json:"lines"`
+	Percent    int      `json:"percent"`
+	LineCounts
+}
+
+// MultiRepoStats holds per-repository statistics and their combined totals
+type MultiRepoStats struct {
+	Repos    []RepoStats `json:"repos"`
+	Combined RepoStats   `json:"combined"`
+}
+
+// languages maps extensions that belong together to one language name;
+// unlisted extensions are reported under their upper-cased extension
+var languages = map[string]string{
+	"ts": "TypeScript", "tsx": "TypeScript",
+	"js": "JavaScript", "jsx": "JavaScript",
+	"go": "Go", "py": "Python", "java": "Java", "rb": "Ruby", "rs": "Rust",
+	"php": "PHP", "cs": "C#", "kt": "Kotlin", "swift": "Swift", "dart": "Dart",
+	"c": "C", "h": "C", "cpp": "C++", "hpp": "C++",
+	"vue": "Vue", "svelte": "Svelte", "html": "HTML",
+	"css": "CSS", "scss": "SCSS", "sass": "SCSS", "less": "Less",
+	"yaml": "YAML", "yml": "YAML", "json": "JSON", "toml": "TOML", "xml": "XML",
+	"sh": "Shell", "bash": "Shell", "zsh": "Shell", "fish": "Shell",
+	"sql": "SQL", "lua": "Lua",
+}
+
+// LanguageOf returns the language name for a file extension
+func LanguageOf(ext string) string {
+	if language, ok := languages[ext]; ok {
+		return language
+	}
+	return strings.ToUpper(ext)
+}
+
+// GroupLanguages merges extension statistics into languages, sorted by lines
+func GroupLanguages(extensions []FileStats) []LanguageStats {
+	byLanguage := make(map[string]*LanguageStats)
+	totalLines := 0
+	for _, ext := range extensions {
+		name := LanguageOf(ext.Extension)
+		if byLanguage[name] == nil {
+			byLanguage[name] = &LanguageStats{Language: name}
+		}
+		language := byLanguage[name]
+		language.Extensions = append(language.Extensions, ext.Extension)
+		language.Files += ext.Files
+		language.Lines += ext.Lines
+		language.Add(ext.LineCounts)
+		totalLines += ext.Lines
+	}
+
+	var result []LanguageStats
+	for _, language := range byLanguage {
+		sort.Strings(language.Extensions)
+		if totalLines > 0 {
+			language.Percent = language.Lines * 100 / totalLines
+		}
+		result = append(result, *language)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Lines != result[j].Lines {
+			return result[i].Lines > result[j].Lines
+		}
+		return result[i].Language < result[j].Language
+	})
+	return result
+}
+
+// AggregateRepoStats combines repository statistics; authors are counted once across repositories
+func AggregateRepoStats(repos []RepoStats) MultiRepoStats {
+	combined := RepoStats{Repository: "combined"}
+	extStats := make(map[string]*FileStats)
+	authors := make(map[string]bool)
+
+	for _, repo := range repos {
+		combined.TotalFiles += repo.TotalFiles
+		combined.TotalLines += repo.TotalLines
+		combined.Branches += repo.Branches
+		combined.Commits += repo.Commits
+		combined.LinesAdded += repo.LinesAdded
+		combined.LinesDeleted += repo.LinesDeleted
+		combined.Tickets = append(combined.Tickets, repo.Tickets...)
+		combined.GeneratedLines += repo.GeneratedLines
+		combined.Excluded += repo.Excluded
+		for _, file := range repo.Generated {
+			combined.Generated = append(combined.Generated, filepath.Base(repo.Repository)+"/"+file)
+		}
+		for _, author := range repo.authorNames {
+			authors[author] = true
+		}
+
+		for _, ext := range repo.Extensions {
+			if extStats[ext.Extension] == nil {
+				extStats[ext.Extension] = &FileStats{Extension: ext.Extension}
+			}
+			extStats[ext.Extension].Files += ext.Files
+			extStats[ext.Extension].Lines += ext.Lines
+			extStats[ext.Extension].Add(ext.LineCounts)
+		}
+	}
+	combined.Authors = len(authors)
+	combined.Tickets = RemoveDuplicates(combined.Tickets)
+
+	for _, stats := range extStats {
+		if combined.TotalLines > 0 {
+			stats.Percent = stats.Lines * 100 / combined.TotalLines
+		}
+		if stats.Files > 0 {
+			stats.AvgLines = stats.Lines / stats.Files
+		}
+		combined.Extensions = append(combined.Extensions, *stats)
+	}
+	sort.Slice(combined.Extensions, func(i, j int) bool {
+		return combined.Extensions[i].Lines > combined.Extensions[j].Lines
+	})
+	combined.Languages = GroupLanguages(combined.Extensions)
+
+	return MultiRepoStats{Repos: repos, Combined: combined}
+}
@@ -0,0 +1,250 @@
+package git
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// LineCounts splits the lines of a file into code, comment and blank lines
+type LineCounts struct {
+	Code     int `json:"code"`
+	Comments int `json:"comments"`
+	Blank    int `json:"blank"`
+}
+
+// Add adds other to the counts
+func (c *LineCounts) Add(other LineCounts) {
+	c.Code += other.Code
+	c.Comments += other.Comments
+	c.Blank += other.Blank
+}
+
+// commentStyle describes the comment syntax of a language
+type commentStyle struct {
+	line       []string
+	blockStart string
+	blockEnd   string
+}
+
+var (
+	cStyle    = commentStyle{line: []string{"//"}, blockStart: "/*", blockEnd: "*/"}
+	hashStyle = commentStyle{line: []string{"#"}}
+	markup    = commentStyle{blockStart: "<!--", blockEnd: "-->"}
+)
+
+// commentStyles maps language names (see LanguageOf) to their comment syntax;
+// languages without an entry have all non-blank lines counted as code
+var commentStyles = map[string]commentStyle{
+	"Go": cStyle, "TypeScript": cStyle, "JavaScript": cStyle, "Java": cStyle,
+	"C": cStyle, "C++": cStyle, "C#": cStyle, "Kotlin": cStyle, "Swift": cStyle,
+	"Dart": cStyle, "Rust": cStyle, "Scala": cStyle, "SCSS": cStyle, "Less": cStyle,
+	"PHP":  {line: []string{"//", "#"}, blockStart: "/*", blockEnd: "*/"},
+	"CSS":  {blockStart: "/*", blockEnd: "*/"},
+	"SQL":  {line: []string{"--"}, blockStart: "/*", blockEnd: "*/"},
+	"Lua":  {line: []string{"--"}, blockStart: "--[[", blockEnd: "]]"},
+	"HTML": markup, "XML": markup, "Vue": markup, "Svelte": markup,
+	"Python": hashStyle, "Ruby": hashStyle, "Shell": hashStyle, "YAML": hashStyle,
+	"TOML": hashStyle, "R": hashStyle, "PL": hashStyle, "PM": hashStyle,
+	"INI": {line: []string{";", "#"}},
+}
+
+// generatedNames are lock files and similar machine-written files, matched against the base name
+var generatedNames = []string{
+	"package-lock.json", "npm-shrinkwrap.json", "pnpm-lock.yaml", "composer.lock",
+	"*.min.js", "*.min.css", "*.bundle.js", "*.map",
+	"*.pb.go", "*_generated.go", "*.generated.*", "*.g.dart", "*.freezed.dart",
+}
+
+// generatedHeader matches the markers code generators put at the top of a file
+var generatedHeader = regexp.MustCompile(`(?i)(code generated .*do not edit|@generated|auto-?generated|this file is generated)`)
+
+// Thresholds above which a file is considered minified
+const (
+	minifiedLineLength    = 1000
+	minifiedAverageLength = 300
+)
+
+// fileAnalysis is the result of reading one source file
+type fileAnalysis struct {
+	Lines      int
+	Counts     LineCounts
+	Complexity int // indentation complexity, see indentComplexity
+	Generated  bool
+	Binary     bool
+}
+
+// analyzeFile counts the lines of a file and detects generated, minified and binary content
+func analyzeFile(filePath, ext string) (fileAnalysis, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fileAnalysis{}, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return fileAnalysis{Binary: true}, nil
+	}
+
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return fileAnalysis{}, nil
+	}
+	lines := strings.Split(text, "\n")
+
+	result := fileAnalysis{
+		Lines:      len(lines),
+		Counts:     countLines(lines, commentStyles[LanguageOf(ext)]),
+		Complexity: indentComplexity(lines),
+		Generated:  isGeneratedName(filePath) || hasGeneratedHeader(lines) || isMinified(lines, len(data)),
+	}
+	return result, nil
+}
+
+// countLines classifies each line as code, comment or blank; a line with code
+// next to a comment counts as code
+func countLines(lines []string, style commentStyle) LineCounts {
+	var counts LineCounts
+	inBlock := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			if inBlock {
+				counts.Comments++
+			} else {
+				counts.Blank++
+			}
+		case inBlock:
+			counts.Comments++
+			if end := strings.Index(line, style.blockEnd); end >= 0 {
+				inBlock = false
+				if rest := strings.TrimSpace(line[end+len(style.blockEnd):]); rest != "" {
+					counts.Comments--
+					counts.Code++
+				}
+			}
+		case style.blockStart != "" && strings.HasPrefix(line, style.blockStart):
+			rest := line[len(style.blockStart):]
+			end := strings.Index(rest, style.blockEnd)
+			if end < 0 {
+				inBlock = true
+				counts.Comments++
+			} else if strings.TrimSpace(rest[end+len(style.blockEnd):]) != "" {
+				counts.Code++
+			} else {
+				counts.Comments++
+			}
+		case hasAnyPrefix(line, style.line):
+			counts.Comments++
+		default:
+			counts.Code++
+		}
+	}
+	return counts
+}
+
+// indentComplexity sums the indentation levels of all non-blank lines, a language-neutral
+// proxy for nesting depth; a tab is one level, spaces are counted in the file's smallest indent
+func indentComplexity(lines []string) int {
+	unit := 0
+	for _, line := range lines {
+		if spaces := len(line) - len(strings.TrimLeft(line, " ")); spaces > 0 && strings.TrimSpace(line) != "" {
+			if unit == 0 || spaces < unit {
+				unit = spaces
+			}
+		}
+	}
+	if unit == 0 || unit > 8 {
+		unit = 4
+	}
+
+	total := 0
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		indent := line[:len(line)-len(trimmed)]
+		total += strings.Count(indent, "\t") + strings.Count(indent, " ")/unit
+	}
+	return total
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGeneratedName reports whether the file name marks a lock file, bundle or generated source
+func isGeneratedName(filePath string) bool {
+	base := path.Base(filePath)
+	for _, pattern := range generatedNames {
+		if matched, _ := path.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGeneratedHeader looks for a generator marker in the first lines of a file
+func hasGeneratedHeader(lines []string) bool {
+	for _, line := range lines[:min(len(lines), 5)] {
+		if generatedHeader.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMinified reports whether a file consists of very long lines
+func isMinified(lines []string, size int) bool {
+	if size/len(lines) > minifiedAverageLength {
+		return true
+	}
+	for _, line := range lines {
+		if len(line) > minifiedLineLength {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExcluded reports whether a repository-relative path matches one of the exclude patterns
+func IsExcluded(relPath string, patterns []string) bool {
+	return MatchesAnyPattern(relPath, patterns)
+}
+
+// MatchesAnyPattern reports whether a repository-relative path matches one of the patterns.
+// "dir/" matches a directory at any depth ("a/b/" only at that path), other patterns are
+// globs matched against the full path and the base name.
+func MatchesAnyPattern(relPath string, patterns []string) bool {
+	dirs := strings.Split(path.Dir(relPath), "/")
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+			if strings.Contains(dir, "/") {
+				if strings.HasPrefix(relPath, dir+"/") {
+					return true
+				}
+				continue
+			}
+			for _, d := range dirs {
+				if matched, _ := path.Match(dir, d); matched {
+					return true
+				}
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, relPath); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, path.Base(relPath)); matched {
+			return true
+		}
+	}
+	return false
+}
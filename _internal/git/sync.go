@@ -0,0 +1,140 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"strconv"
+	"strings"
+)
+
+// BranchTracking is a local branch that differs from its upstream
+type BranchTracking struct {
+	Branch   string `json:"branch"`
+	Upstream string `json:"upstream"`
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+	Gone     bool   `json:"gone,omitempty"` // the upstream branch was deleted
+}
+
+// SyncResult is what SyncRepo did in one repository
+type SyncResult struct {
+	Repository  string           `json:"repository"`
+	Branch      string           `json:"branch"` // the checked out branch
+	MainBranch  string           `json:"main_branch"`
+	Skipped     string           `json:"skipped,omitempty"` // why the repository was left alone
+	MainStatus  string           `json:"main_status,omitempty"`
+	FastForward int              `json:"fast_forward"` // commits the main branch moved
+	Branches    []BranchTracking `json:"branches"`
+}
+
+// SyncRepo fetches origin with prune, fast-forwards the main branch when that is safe (no local
+// commits, not checked out in another worktree) and reports the branches that differ from their
+// upstream. Repositories with uncommitted changes are skipped.
+func SyncRepo(ctx context.Context, repoPath string) (*SyncResult, error) {
+	if !IsGitRepoAtPath(repoPath) {
+		return nil, fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	result := &SyncResult{Repository: repoPath, MainBranch: MainBranchAt(repoPath)}
+	result.Branch, _ = GetCurrentBranch(repoPath)
+	if sys.RunCommandInDirContext(ctx, repoPath, "git", "remote", "get-url", "origin").ExitCode != 0 {
+		result.Skipped = "no origin remote"
+		return result, nil
+	}
+
+	status := sys.RunCommandInDirContext(ctx, repoPath, "git", "status", "--porcelain", "--untracked-files=no")
+	if status.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to get status: %s", status.Stderr)
+	}
+	if status.Stdout != "" {
+		result.Skipped = io.Plural(len(strings.Split(status.Stdout, "\n")), "uncommitted change")
+		return result, nil
+	}
+
+	fetch := sys.RunCommandInDirContext(ctx, repoPath, "git", "fetch", "--prune", "--quiet", "origin")
+	if fetch.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to fetch: %s", fetch.Stderr)
+	}
+
+	result.MainStatus, result.FastForward = fastForwardMain(ctx, repoPath, result.MainBranch, result.Branch)
+
+	branches, err := trackingBranches(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	result.Branches = branches
+	return result, nil
+}
+
+// fastForwardMain moves the main branch to origin/<main> if it has no commits of its own
+func fastForwardMain(ctx context.Context, repoPath, main, current string) (string, int) {
+	remote := "origin/" + main
+	if sys.RunCommandInDirContext(ctx, repoPath, "git", "rev-parse", "--verify", "--quiet", remote).ExitCode != 0 {
+		return "no " + remote, 0
+	}
+	if sys.RunCommandInDirContext(ctx, repoPath, "git", "rev-parse", "--verify", "--quiet", "refs/heads/"+main).ExitCode != 0 {
+		return "no local " + main, 0
+	}
+
+	ahead, behind := aheadBehind(ctx, repoPath, remote, main)
+	switch {
+	case ahead > 0 && behind > 0:
+		return fmt.Sprintf("diverged (↑%d ↓%d)", ahead, behind), 0
+	case ahead > 0:
+		return fmt.Sprintf("%s not pushed", io.Plural(ahead, "commit")), 0
+	case behind == 0:
+		return "up to date", 0
+	}
+
+	if main == current {
+		merge := sys.RunCommandInDirContext(ctx, repoPath, "git", "merge", "--ff-only", "--quiet", remote)
+		if merge.ExitCode != 0 {
+			return "fast-forward failed: " + merge.Stderr, 0
+		}
+		return fmt.Sprintf("fast-forwarded %s", io.Plural(behind, "commit")), behind
+	}
+
+	// Moving the ref under another worktree would leave that checkout with phantom changes
+	worktrees := sys.RunCommandInDirContext(ctx, repoPath, "git", "worktree", "list", "--porcelain")
+	for _, line := range strings.Split(worktrees.Stdout, "\n") {
+		if line == "branch refs/heads/"+main {
+			return fmt.Sprintf("%s behind, checked out in another worktree", io.Plural(behind, "commit")), 0
+		}
+	}
+
+	old := sys.RunCommandInDirContext(ctx, repoPath, "git", "rev-parse", "refs/heads/"+main).Stdout
+	update := sys.RunCommandInDirContext(ctx, repoPath, "git", "update-ref", "-m", "gsync: fast-forward", "refs/heads/"+main, remote, old)
+	if update.ExitCode != 0 {
+		return "fast-forward failed: " + update.Stderr, 0
+	}
+	return fmt.Sprintf("fast-forwarded %s", io.Plural(behind, "commit")), behind
+}
+
+// trackingBranches returns the local branches that are ahead of or behind their upstream, or lost it
+func trackingBranches(ctx context.Context, repoPath string) ([]BranchTracking, error) {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "for-each-ref",
+		"--format=%(refname:short)%00%(upstream:short)%00%(upstream:track,nobracket)", "refs/heads")
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list branches: %s", result.Stderr)
+	}
+
+	branches := []BranchTracking{}
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		fields := strings.SplitN(line, "\x00", 3)
+		if len(fields) != 3 || fields[1] == "" || fields[2] == "" {
+			continue
+		}
+		tracking := BranchTracking{Branch: fields[0], Upstream: fields[1], Gone: fields[2] == "gone"}
+		// "ahead 1", "behind 2" or "ahead 1, behind 2"
+		for _, part := range strings.Split(fields[2], ", ") {
+			if count, ok := strings.CutPrefix(part, "ahead "); ok {
+				tracking.Ahead, _ = strconv.Atoi(count)
+			} else if count, ok := strings.CutPrefix(part, "behind "); ok {
+				tracking.Behind, _ = strconv.Atoi(count)
+			}
+		}
+		branches = append(branches, tracking)
+	}
+	return branches, nil
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"cli-go/_internal/sys"
+)
+
+// GetLatestTag returns the most recent tag reachable from ref (HEAD when empty)
+func GetLatestTag(repoPath, ref string) (string, error) {
+	args := []string{"describe", "--tags", "--abbrev=0"}
+	if ref != "" {
+		args = append(args, ref)
+	}
+
+	result := sys.RunCommandInDir(repoPath, "git", args...)
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("no tag found before %s: %s", defaultRef(ref), result.Stderr)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// GetReleaseRange returns the range of the latest release: the latest tag to HEAD, or the previous
+// tag to HEAD when HEAD is tagged, which is when release notes are usually written
+func GetReleaseRange(repoPath string) (string, error) {
+	tag, err := GetLatestTag(repoPath, "")
+	if err != nil {
+		return "", err
+	}
+
+	tagged := sys.RunCommandInDir(repoPath, "git", "rev-parse", tag+"^{commit}")
+	head := sys.RunCommandInDir(repoPath, "git", "rev-parse", "HEAD")
+	if tagged.ExitCode != 0 || tagged.Stdout != head.Stdout {
+		return tag + "..HEAD", nil
+	}
+
+	previous, err := GetLatestTag(repoPath, tag+"^")
+	if err != nil {
+		return "HEAD", nil // the first release covers the whole history
+	}
+	return previous + "..HEAD", nil
+}
+
+// GetRemoteURL returns the web URL of the origin remote (ssh remotes are converted to https)
+func GetRemoteURL(repoPath string) (string, error) {
+	result := sys.RunCommandInDir(repoPath, "git", "remote", "get-url", "origin")
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("failed to get origin url: %s", result.Stderr)
+	}
+
+	url := strings.TrimSuffix(strings.TrimSpace(result.Stdout), ".git")
+	if strings.HasPrefix(url, "git@") {
+		url = "https://" + strings.Replace(strings.TrimPrefix(url, "git@"), ":", "/", 1)
+	}
+	return url, nil
+}
+
+// defaultRef returns ref or HEAD when empty
+func defaultRef(ref string) string {
+	if ref == "" {
+		return "HEAD"
+	}
+	return ref
+}
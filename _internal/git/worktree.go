@@ -0,0 +1,159 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Worktree is a working tree of a repository with its status
+type Worktree struct {
+	Path       string    `json:"path"`
+	Branch     string    `json:"branch,omitempty"` // empty when detached
+	Head       string    `json:"head"`
+	Main       bool      `json:"main"` // the repository's own working tree, never removed
+	Locked     bool      `json:"locked,omitempty"`
+	Prunable   bool      `json:"prunable,omitempty"` // its directory is gone
+	Changes    int       `json:"changes"`            // modified, staged and untracked files
+	Upstream   string    `json:"upstream,omitempty"`
+	Ahead      int       `json:"ahead"`
+	Behind     int       `json:"behind"`
+	LastCommit time.Time `json:"last_commit"`
+	Subject    string    `json:"subject"`
+}
+
+// ListWorktrees returns the worktrees of a repository, the main one first, with their status
+func ListWorktrees(ctx context.Context, repoPath string) ([]Worktree, error) {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "worktree", "list", "--porcelain")
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list worktrees: %s", result.Stderr)
+	}
+
+	// Porcelain output: one block per worktree, separated by blank lines
+	var worktrees []Worktree
+	for i, block := range strings.Split(result.Stdout, "\n\n") {
+		var wt Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.Path = value
+			case "HEAD":
+				wt.Head = value
+			case "branch":
+				wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "locked":
+				wt.Locked = true
+			case "prunable":
+				wt.Prunable = true
+			}
+		}
+		if wt.Path == "" {
+			continue
+		}
+		wt.Main = i == 0
+		if !wt.Prunable {
+			readWorktreeStatus(ctx, &wt)
+		}
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees, nil
+}
+
+// readWorktreeStatus fills in uncommitted changes, ahead/behind and the last commit
+func readWorktreeStatus(ctx context.Context, wt *Worktree) {
+	if status := sys.RunCommandInDirContext(ctx, wt.Path, "git", "status", "--porcelain"); status.ExitCode == 0 && status.Stdout != "" {
+		wt.Changes = len(strings.Split(status.Stdout, "\n"))
+	}
+	if upstream := sys.RunCommandInDirContext(ctx, wt.Path, "git", "rev-parse", "--abbrev-ref", "@{upstream}"); upstream.ExitCode == 0 {
+		wt.Upstream = upstream.Stdout
+		wt.Ahead, wt.Behind = aheadBehind(ctx, wt.Path, "@{upstream}", "HEAD")
+	}
+	if log := sys.RunCommandInDirContext(ctx, wt.Path, "git", "log", "-1", "--format=%ct%x00%s"); log.ExitCode == 0 {
+		seconds, subject, _ := strings.Cut(log.Stdout, "\x00")
+		if unix, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			wt.LastCommit = time.Unix(unix, 0)
+		}
+		wt.Subject = subject
+	}
+}
+
+// aheadBehind counts the commits only on to (ahead) and only on from (behind)
+func aheadBehind(ctx context.Context, repoPath, from, to string) (int, int) {
+	result := sys.RunCommandInDirContext(ctx, repoPath, "git", "rev-list", "--left-right", "--count", from+"..."+to)
+	if result.ExitCode != 0 {
+		return 0, 0
+	}
+	fields := strings.Fields(result.Stdout)
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	behind, _ := strconv.Atoi(fields[0])
+	ahead, _ := strconv.Atoi(fields[1])
+	return ahead, behind
+}
+
+// WorktreeDir returns the conventional directory for a branch's worktree: a sibling of the
+// repository named <repo>--<branch>, with slashes in the branch replaced by dashes
+func WorktreeDir(repoRoot, branch string) string {
+	name := filepath.Base(repoRoot) + "--" + strings.ReplaceAll(branch, "/", "-")
+	return filepath.Join(filepath.Dir(repoRoot), name)
+}
+
+// AddWorktree creates a worktree for branch in dir. An existing local branch is checked out, a
+// branch that only exists on origin is tracked, and otherwise a new branch is created from base.
+func AddWorktree(repoRoot, branch, dir, base string) error {
+	var args []string
+	switch {
+	case sys.RunCommandInDir(repoRoot, "git", "show-ref", "-q", "--verify", "refs/heads/"+branch).ExitCode == 0:
+		args = []string{"worktree", "add", dir, branch}
+	case sys.RunCommandInDir(repoRoot, "git", "show-ref", "-q", "--verify", "refs/remotes/origin/"+branch).ExitCode == 0:
+		args = []string{"worktree", "add", "--track", "-b", branch, dir, "origin/" + branch}
+	default:
+		args = []string{"worktree", "add", "--no-track", "-b", branch, dir, base}
+	}
+
+	result := sys.RunCommandInDir(repoRoot, "git", args...)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to add worktree for %s: %s", branch, result.Stderr)
+	}
+	return nil
+}
+
+// RemoveWorktree removes a worktree; it refuses the main worktree, and one with uncommitted or
+// untracked files unless force is set
+func RemoveWorktree(repoRoot string, wt Worktree, force bool) error {
+	if wt.Main {
+		return fmt.Errorf("%s is the main worktree of the repository", wt.Path)
+	}
+	if wt.Changes > 0 && !force {
+		return fmt.Errorf("%s has %s, commit or stash them first (or use --force)", wt.Path, io.Plural(wt.Changes, "uncommitted change"))
+	}
+
+	args := []string{"worktree", "remove", wt.Path}
+	if force {
+		args = []string{"worktree", "remove", "--force", wt.Path}
+	}
+	result := sys.RunCommandInDir(repoRoot, "git", args...)
+	if result.ExitCode != 0 {
+		return fmt.Errorf("failed to remove worktree %s: %s", wt.Path, result.Stderr)
+	}
+	sys.RunCommandInDir(repoRoot, "git", "worktree", "prune")
+	return nil
+}
+
+// FindWorktree finds a worktree by branch, path or directory name
+func FindWorktree(worktrees []Worktree, query string) (*Worktree, error) {
+	abs, _ := filepath.Abs(query)
+	for i, wt := range worktrees {
+		if wt.Branch == query || wt.Path == abs || filepath.Base(wt.Path) == query {
+			return &worktrees[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no worktree for %s", query)
+}
@@ -1,16 +1,19 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"cli-go/_internal/config"
 )
 
 // SearchPRsByQuery searches for PRs using gh CLI
-func SearchPRsByQuery(owner, repo, query string) ([]PR, error) {
-	cmd := exec.Command("gh", "pr", "list", "--repo", fmt.Sprintf("%s/%s", owner, repo), "--search", query, "--state", "all", "--json", "number,title,url,state,createdAt,updatedAt,body,isDraft,headRefName")
+func SearchPRsByQuery(ctx context.Context, owner, repo, query string) ([]PR, error) {
+	cmd := exec.CommandContext(ctx, "gh", "pr", "list", "--repo", fmt.Sprintf("%s/%s", owner, repo), "--search", query, "--state", "all", "--json", "number,title,url,state,createdAt,updatedAt,body,isDraft,headRefName")
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -32,8 +35,8 @@ func SearchPRsByQuery(owner, repo, query string) ([]PR, error) {
 }
 
 // GetPRDetails gets detailed information about a specific PR
-func GetPRDetails(owner, repo string, number int) (*PR, error) {
-	cmd := exec.Command("gh", "pr", "view", fmt.Sprintf("%d", number), "--repo", fmt.Sprintf("%s/%s", owner, repo), "--json", "number,title,url,state,createdAt,updatedAt,body,isDraft,headRefName")
+func GetPRDetails(ctx context.Context, owner, repo string, number int) (*PR, error) {
+	cmd := exec.CommandContext(ctx, "gh", "pr", "view", fmt.Sprintf("%d", number), "--repo", fmt.Sprintf("%s/%s", owner, repo), "--json", "number,title,url,state,createdAt,updatedAt,body,isDraft,headRefName")
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -51,16 +54,57 @@ func GetPRDetails(owner, repo string, number int) (*PR, error) {
 	return &pr, nil
 }
 
+// PRListLimit is how many of the most recent PRs ListPRsByHead looks at
+const PRListLimit = 500
+
+// ListPRsByHead lists the recent PRs of a repository in one call, keyed by head branch;
+// PRs from forks are skipped so their branch names don't collide with the repository's own,
+// and when a branch has several PRs the open one, else the most recent one, is kept.
+// truncated reports that PRListLimit was reached, so older branches may have no entry.
+func ListPRsByHead(ctx context.Context, owner, repo string) (prs map[string]PR, truncated bool, err error) {
+	cmd := exec.CommandContext(ctx, "gh", "pr", "list", "--repo", fmt.Sprintf("%s/%s", owner, repo), "--state", "all", "--limit", fmt.Sprintf("%d", PRListLimit), "--json", "number,title,url,state,createdAt,updatedAt,isDraft,headRefName,headRepositoryOwner,reviewDecision")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list PRs: %v", err)
+	}
+
+	var listed []struct {
+		PR
+		HeadRepositoryOwner struct {
+			Login string `json:"login"`
+		} `json:"headRepositoryOwner"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil {
+		return nil, false, fmt.Errorf("failed to parse PR data: %v", err)
+	}
+
+	byHead := make(map[string]PR)
+	for _, item := range listed {
+		if !strings.EqualFold(item.HeadRepositoryOwner.Login, owner) {
+			continue
+		}
+		pr := item.PR
+		pr.Owner = owner
+		pr.Repo = repo
+		existing, ok := byHead[pr.HeadRefName]
+		if !ok || (pr.State == "OPEN" && existing.State != "OPEN") || (existing.State != "OPEN" && pr.UpdatedAt.After(existing.UpdatedAt)) {
+			byHead[pr.HeadRefName] = pr
+		}
+	}
+	return byHead, len(listed) >= PRListLimit, nil
+}
+
 // GetUserOpenPRs gets open PRs for a specific user
-func GetUserOpenPRs(userEmail string, owner, repo string) ([]PR, error) {
+func GetUserOpenPRs(ctx context.Context, userEmail string, owner, repo string) ([]PR, error) {
 	// Search for PRs by user email in the body or title
 	query := fmt.Sprintf("author:%s", userEmail)
-	return SearchPRsByQuery(owner, repo, query)
+	return SearchPRsByQuery(ctx, owner, repo, query)
 }
 
 // GetAuthenticatedUser gets the currently authenticated GitHub user
-func GetAuthenticatedUser() (string, error) {
-	cmd := exec.Command("gh", "api", "user", "--jq", ".login")
+func GetAuthenticatedUser(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "gh", "api", "user", "--jq", ".login")
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -71,8 +115,8 @@ func GetAuthenticatedUser() (string, error) {
 }
 
 // CheckGitHubAuth checks if GitHub CLI is authenticated
-func CheckGitHubAuth() error {
-	cmd := exec.Command("gh", "auth", "status")
+func CheckGitHubAuth(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "gh", "auth", "status")
 	return cmd.Run()
 }
 
@@ -93,3 +137,20 @@ func OpenInBrowser(url string) error {
 
 	return cmd.Start()
 }
+
+// RepoSlugs returns owner/repo for every configured repository with GitHub coordinates
+func RepoSlugs(repos []config.RepoConfig) []string {
+	var slugs []string
+	for _, repo := range repos {
+		if repo.Owner != "" && repo.Repo != "" {
+			slugs = append(slugs, repo.Owner+"/"+repo.Repo)
+		}
+	}
+	return slugs
+}
+
+// SplitSlug splits owner/repo
+func SplitSlug(slug string) (owner, repo string) {
+	owner, repo, _ = strings.Cut(slug, "/")
+	return owner, repo
+}
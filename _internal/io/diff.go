@@ -0,0 +1,178 @@
+package io
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// ANSI styles for diff output
+const (
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1m"
+	ansiDim   = "\033[2m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiCyan  = "\033[36m"
+)
+
+// UseColor reports whether stdout is a terminal and NO_COLOR is not set
+func UseColor() bool {
+	return os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// TerminalWidth returns the width of stdout, or fallback when it is not a terminal
+func TerminalWidth(fallback int) int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return fallback
+}
+
+func paint(text, style string, color bool) string {
+	if !color || text == "" {
+		return text
+	}
+	return style + text + ansiReset
+}
+
+// RenderUnifiedDiff colors a unified diff: file headers bold, hunk headers cyan, additions green, removals red
+func RenderUnifiedDiff(patch string, color bool) string {
+	var output strings.Builder
+	inHeader := false
+	for _, line := range strings.Split(strings.TrimRight(patch, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHeader = true
+			output.WriteString(paint(line, ansiBold, color))
+		case strings.HasPrefix(line, "@@"):
+			inHeader = false
+			output.WriteString(paint(line, ansiCyan, color))
+		case inHeader:
+			output.WriteString(paint(line, ansiBold, color))
+		case strings.HasPrefix(line, "+"):
+			output.WriteString(paint(line, ansiGreen, color))
+		case strings.HasPrefix(line, "-"):
+			output.WriteString(paint(line, ansiRed, color))
+		default:
+			output.WriteString(line)
+		}
+		output.WriteString("\n")
+	}
+	return output.String()
+}
+
+// sideBySide renders the hunks of a unified diff in two columns, old on the left and new on the right
+type sideBySide struct {
+	output  strings.Builder
+	color   bool
+	content int // width of the text in each column
+	left    int // next old line number
+	right   int // next new line number
+	removed []string
+	added   []string
+}
+
+// RenderSideBySide renders a unified diff as two columns fitting width, pairing removed and added lines
+func RenderSideBySide(patch string, width int, color bool) string {
+	// Each column: 5-char line number gutter + text; columns are separated by " │ "
+	r := &sideBySide{color: color, content: max((width-3)/2-5, 10)}
+
+	inHeader := false
+	for _, line := range strings.Split(strings.TrimRight(patch, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			r.flush()
+			inHeader = true
+			if r.output.Len() > 0 {
+				r.output.WriteString("\n")
+			}
+			r.output.WriteString(paint(diffTitle(line), ansiBold, color) + "\n")
+		case strings.HasPrefix(line, "@@"):
+			r.flush()
+			inHeader = false
+			r.left, r.right = hunkStart(line)
+			r.output.WriteString(paint(line, ansiCyan, color) + "\n")
+		case inHeader:
+			// Show what git says about modes, renames and binaries, skip index and ---/+++ lines
+			if !strings.HasPrefix(line, "index ") && !strings.HasPrefix(line, "--- ") && !strings.HasPrefix(line, "+++ ") {
+				r.output.WriteString(paint(line, ansiDim, color) + "\n")
+			}
+		case strings.HasPrefix(line, "-"):
+			r.removed = append(r.removed, line[1:])
+		case strings.HasPrefix(line, "+"):
+			r.added = append(r.added, line[1:])
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file"
+		default:
+			r.flush()
+			text := strings.TrimPrefix(line, " ")
+			r.row(strconv.Itoa(r.left), text, "", strconv.Itoa(r.right), text, "")
+			r.left++
+			r.right++
+		}
+	}
+	r.flush()
+	return r.output.String()
+}
+
+// flush pairs up the pending removed and added lines
+func (r *sideBySide) flush() {
+	for i := 0; i < max(len(r.removed), len(r.added)); i++ {
+		leftNo, leftText, leftStyle := "", "", ""
+		rightNo, rightText, rightStyle := "", "", ""
+		if i < len(r.removed) {
+			leftNo, leftText, leftStyle = strconv.Itoa(r.left), r.removed[i], ansiRed
+			r.left++
+		}
+		if i < len(r.added) {
+			rightNo, rightText, rightStyle = strconv.Itoa(r.right), r.added[i], ansiGreen
+			r.right++
+		}
+		r.row(leftNo, leftText, leftStyle, rightNo, rightText, rightStyle)
+	}
+	r.removed, r.added = nil, nil
+}
+
+func (r *sideBySide) row(leftNo, leftText, leftStyle, rightNo, rightText, rightStyle string) {
+	r.output.WriteString(paint(fmt.Sprintf("%4s ", leftNo), ansiDim, r.color))
+	r.output.WriteString(paint(r.cell(leftText), leftStyle, r.color))
+	r.output.WriteString(" │ ")
+	r.output.WriteString(paint(fmt.Sprintf("%4s ", rightNo), ansiDim, r.color))
+	r.output.WriteString(paint(strings.TrimRight(r.cell(rightText), " "), rightStyle, r.color))
+	r.output.WriteString("\n")
+}
+
+// cell expands tabs and fits text to the column width
+func (r *sideBySide) cell(text string) string {
+	text = strings.ReplaceAll(text, "\t", "    ")
+	return runewidth.FillRight(runewidth.Truncate(text, r.content, "…"), r.content)
+}
+
+// diffTitle turns "diff --git a/x b/y" into "x" or "x → y"
+func diffTitle(line string) string {
+	paths := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.Index(paths, " b/"); strings.HasPrefix(paths, "a/") && i > 0 {
+		from, to := paths[2:i], paths[i+3:]
+		if from != to {
+			return from + " → " + to
+		}
+		return to
+	}
+	return paths
+}
+
+// hunkStart parses the old and new start lines of "@@ -l,s +r,s @@"
+func hunkStart(line string) (int, int) {
+	var left, right int
+	fields := strings.Fields(line)
+	if len(fields) >= 3 {
+		left, _ = strconv.Atoi(strings.SplitN(strings.TrimPrefix(fields[1], "-"), ",", 2)[0])
+		right, _ = strconv.Atoi(strings.SplitN(strings.TrimPrefix(fields[2], "+"), ",", 2)[0])
+	}
+	return left, right
+}
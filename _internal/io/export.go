@@ -0,0 +1,101 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"cli-go/_internal/ai"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// ConversationHTML renders a conversation as a self-contained HTML page with highlighted code blocks
+func ConversationHTML(conv *ai.Conversation, withUsage bool) (string, error) {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&codeBlockRenderer{}, 200)),
+		),
+	)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, htmlHeader, html.EscapeString(conv.Title))
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<p class=\"meta\">%s</p>\n", html.EscapeString(conv.Title), html.EscapeString(conv.Subtitle()))
+
+	for _, m := range conv.Messages {
+		var body bytes.Buffer
+		if err := md.Convert([]byte(m.Content), &body); err != nil {
+			return "", fmt.Errorf("failed to render message: %v", err)
+		}
+		fmt.Fprintf(&b, "<section class=\"turn %s\">\n<h3>%s</h3>\n%s</section>\n",
+			html.EscapeString(m.Role), html.EscapeString(m.TurnHeading(withUsage)), body.String())
+	}
+
+	if withUsage {
+		total := conv.TotalUsage()
+		fmt.Fprintf(&b, "<p class=\"meta\"><strong>Total tokens:</strong> %d in / %d out</p>\n", total.InputTokens, total.OutputTokens)
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String(), nil
+}
+
+// codeBlockRenderer renders fenced code blocks with chroma using inline styles
+type codeBlockRenderer struct{}
+
+// RegisterFuncs overrides goldmark's default fenced code block rendering
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+// renderFencedCodeBlock highlights the block, falling back to an escaped <pre> on error
+func (r *codeBlockRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	block := node.(*ast.FencedCodeBlock)
+	var code strings.Builder
+	lines := block.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		code.Write(segment.Value(source))
+	}
+
+	highlighted, err := highlightCode(code.String(), string(block.Language(source)), chromahtml.New(chromahtml.WithClasses(false)))
+	if err != nil {
+		highlighted = "<pre><code>" + html.EscapeString(code.String()) + "</code></pre>\n"
+	}
+	w.WriteString(highlighted)
+
+	return ast.WalkSkipChildren, nil
+}
+
+// htmlHeader is the page head with minimal inline CSS so the file has no external dependencies
+const htmlHeader = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 860px; margin: 2rem auto; padding: 0 1rem; line-height: 1.55; color: #1f2328; }
+h1 { margin-bottom: 0.2rem; }
+.meta { color: #656d76; font-size: 0.9rem; }
+.turn { border-top: 1px solid #d0d7de; padding-top: 0.5rem; }
+.turn h3 { font-size: 0.95rem; color: #656d76; font-weight: 600; }
+.turn.user { background: #f6f8fa; padding: 0.5rem 1rem; border-radius: 6px; }
+pre { padding: 0.8rem 1rem; border-radius: 6px; overflow-x: auto; font-size: 0.85rem; }
+code { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; }
+</style>
+</head>
+<body>
+`
@@ -51,3 +51,11 @@ func FormatDuration(d time.Duration) string {
 		return fmt.Sprintf("%.1fd", d.Hours()/24)
 	}
 }
+
+// Plural formats a count with a singular or plural noun, e.g. "1 commit", "3 commits"
+func Plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
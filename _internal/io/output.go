@@ -9,10 +9,12 @@ import (
 	"cli-go/_internal/ai"
 	"cli-go/_internal/sys"
 
+	"github.com/alecthomas/chroma/v2"
 	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/glamour"
+	"golang.org/x/term"
 )
 
 // Tools use DirectOutput() for all output handling
@@ -138,10 +140,10 @@ func FormatTerminalOutputJSON(data interface{}) error {
 		return err
 	}
 
-	// Use chroma for JSON syntax highlighting
+	// Use chroma for JSON syntax highlighting (plain JSON when piped, so scripts can parse it)
 	highlighted, err := highlightJSON(string(jsonData))
-	if err != nil {
-		// Fallback to raw JSON if chroma fails
+	if err != nil || !term.IsTerminal(int(os.Stdout.Fd())) {
+		// Raw JSON if chroma fails or stdout is not a terminal
 		jsonStr := string(jsonData)
 		if !strings.HasSuffix(jsonStr, "\n") {
 			jsonStr += "\n"
@@ -187,30 +189,37 @@ func DataToString(data interface{}) string {
 
 // highlightJSON uses chroma to highlight JSON syntax
 func highlightJSON(jsonStr string) (string, error) {
-	lexer := lexers.Get("json")
-	if lexer == nil {
-		return jsonStr, fmt.Errorf("json lexer not found")
-	}
-
 	formatter := formatters.Get("terminal256")
 	if formatter == nil {
 		return jsonStr, fmt.Errorf("terminal256 formatter not found")
 	}
+	return highlightCode(jsonStr, "json", formatter)
+}
+
+// highlightCode highlights code with chroma's monokai style; unknown languages are detected or left plain
+func highlightCode(code, language string, formatter chroma.Formatter) (string, error) {
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
 
 	style := styles.Get("monokai")
 	if style == nil {
-		return jsonStr, fmt.Errorf("monokai style not found")
+		return code, fmt.Errorf("monokai style not found")
 	}
 
-	iterator, err := lexer.Tokenise(nil, jsonStr)
+	iterator, err := lexer.Tokenise(nil, code)
 	if err != nil {
-		return jsonStr, err
+		return code, err
 	}
 
 	var buf strings.Builder
 	err = formatter.Format(&buf, style, iterator)
 	if err != nil {
-		return jsonStr, err
+		return code, err
 	}
 
 	return buf.String(), nil
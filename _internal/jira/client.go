@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -48,10 +49,10 @@ func (c *Client) createAuthHeader() string {
 }
 
 // makeRequest makes an HTTP request to the Jira API
-func (c *Client) makeRequest(method, endpoint string) ([]byte, error) {
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string) ([]byte, error) {
 	url := c.BaseURL + "/rest/api/3/" + endpoint
 
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
@@ -74,11 +75,11 @@ func (c *Client) makeRequest(method, endpoint string) ([]byte, error) {
 }
 
 // GetIssue retrieves an issue by key
-func (c *Client) GetIssue(issueKey string) (*Issue, error) {
+func (c *Client) GetIssue(ctx context.Context, issueKey string) (*Issue, error) {
 	normalizedKey := NormalizeIssueKey(issueKey, c.DefaultProject)
-	endpoint := fmt.Sprintf("issue/%s?fields=summary,status,assignee,reporter,description,customfield_10087,customfield_10093,customfield_10077", url.PathEscape(normalizedKey))
+	endpoint := fmt.Sprintf("issue/%s?fields=summary,status,issuetype,assignee,reporter,description,customfield_10087,customfield_10093,customfield_10077", url.PathEscape(normalizedKey))
 
-	data, err := c.makeRequest("GET", endpoint)
+	data, err := c.makeRequest(ctx, "GET", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +93,11 @@ func (c *Client) GetIssue(issueKey string) (*Issue, error) {
 }
 
 // GetComments retrieves comments for an issue
-func (c *Client) GetComments(issueKey string) ([]Comment, error) {
+func (c *Client) GetComments(ctx context.Context, issueKey string) ([]Comment, error) {
 	normalizedKey := NormalizeIssueKey(issueKey, c.DefaultProject)
 	endpoint := fmt.Sprintf("issue/%s/comment", url.PathEscape(normalizedKey))
 
-	data, err := c.makeRequest("GET", endpoint)
+	data, err := c.makeRequest(ctx, "GET", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -112,13 +113,13 @@ func (c *Client) GetComments(issueKey string) ([]Comment, error) {
 }
 
 // GetIssueWithComments retrieves an issue with its comments
-func (c *Client) GetIssueWithComments(issueKey string) (*Issue, error) {
-	issue, err := c.GetIssue(issueKey)
+func (c *Client) GetIssueWithComments(ctx context.Context, issueKey string) (*Issue, error) {
+	issue, err := c.GetIssue(ctx, issueKey)
 	if err != nil {
 		return nil, err
 	}
 
-	comments, err := c.GetComments(issueKey)
+	comments, err := c.GetComments(ctx, issueKey)
 	if err != nil {
 		// If comments fail, still return the issue
 		return issue, nil
@@ -129,11 +130,11 @@ func (c *Client) GetIssueWithComments(issueKey string) (*Issue, error) {
 }
 
 // GetChangelog retrieves changelog for an issue
-func (c *Client) GetChangelog(issueKey string) (*Issue, error) {
+func (c *Client) GetChangelog(ctx context.Context, issueKey string) (*Issue, error) {
 	normalizedKey := NormalizeIssueKey(issueKey, c.DefaultProject)
 	endpoint := fmt.Sprintf("issue/%s?expand=changelog", url.PathEscape(normalizedKey))
 
-	data, err := c.makeRequest("GET", endpoint)
+	data, err := c.makeRequest(ctx, "GET", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -147,8 +148,8 @@ func (c *Client) GetChangelog(issueKey string) (*Issue, error) {
 }
 
 // GetCurrentUser retrieves current user information
-func (c *Client) GetCurrentUser() (*User, error) {
-	data, err := c.makeRequest("GET", "myself")
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	data, err := c.makeRequest(ctx, "GET", "myself")
 	if err != nil {
 		return nil, err
 	}
@@ -162,11 +163,11 @@ func (c *Client) GetCurrentUser() (*User, error) {
 }
 
 // SearchUsers searches for users by query
-func (c *Client) SearchUsers(query string) ([]User, error) {
+func (c *Client) SearchUsers(ctx context.Context, query string) ([]User, error) {
 	encodedQuery := url.QueryEscape(query)
 	endpoint := fmt.Sprintf("user/search?query=%s", encodedQuery)
 
-	data, err := c.makeRequest("GET", endpoint)
+	data, err := c.makeRequest(ctx, "GET", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -180,15 +181,15 @@ func (c *Client) SearchUsers(query string) ([]User, error) {
 }
 
 // SearchJQL searches issues using JQL
-func (c *Client) SearchJQL(jql string, maxResults int) (*SearchResults, error) {
+func (c *Client) SearchJQL(ctx context.Context, jql string, maxResults int) (*SearchResults, error) {
 	if maxResults <= 0 {
 		maxResults = 10
 	}
 
 	encodedJQL := url.QueryEscape(jql)
-	endpoint := fmt.Sprintf("search/jql?jql=%s&maxResults=%d&fields=key,summary", encodedJQL, maxResults)
+	endpoint := fmt.Sprintf("search/jql?jql=%s&maxResults=%d&fields=key,summary,status,issuetype", encodedJQL, maxResults)
 
-	data, err := c.makeRequest("GET", endpoint)
+	data, err := c.makeRequest(ctx, "GET", endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +203,7 @@ func (c *Client) SearchJQL(jql string, maxResults int) (*SearchResults, error) {
 }
 
 // GetUserActivityJQL retrieves recent activity for a user
-func (c *Client) GetUserActivityJQL(userAccountID string, maxResults int) (*SearchResults, error) {
+func (c *Client) GetUserActivityJQL(ctx context.Context, userAccountID string, maxResults int) (*SearchResults, error) {
 	var jql string
 	if userAccountID != "" {
 		jql = fmt.Sprintf("updated >= -7d AND (assignee = \"%s\" OR reporter = \"%s\" OR watcher = \"%s\") ORDER BY updated DESC",
@@ -211,11 +212,11 @@ func (c *Client) GetUserActivityJQL(userAccountID string, maxResults int) (*Sear
 		jql = "updated >= -7d AND (assignee = currentUser() OR reporter = currentUser() OR watcher = currentUser()) ORDER BY updated DESC"
 	}
 
-	return c.SearchJQL(jql, maxResults)
+	return c.SearchJQL(ctx, jql, maxResults)
 }
 
 // GetUserModifiedIssues retrieves issues modified by a user
-func (c *Client) GetUserModifiedIssues(userAccountID string, maxResults int) (*SearchResults, error) {
+func (c *Client) GetUserModifiedIssues(ctx context.Context, userAccountID string, maxResults int) (*SearchResults, error) {
 	var jql string
 	if userAccountID != "" {
 		jql = fmt.Sprintf("updated >= -7d AND (assignee = \"%s\" OR reporter = \"%s\") ORDER BY updated DESC",
@@ -224,27 +225,27 @@ func (c *Client) GetUserModifiedIssues(userAccountID string, maxResults int) (*S
 		jql = "updated >= -7d AND (assignee = currentUser() OR reporter = currentUser()) ORDER BY updated DESC"
 	}
 
-	return c.SearchJQL(jql, maxResults)
+	return c.SearchJQL(ctx, jql, maxResults)
 }
 
 // GetIssueWithChangelog retrieves an issue with its changelog
-func (c *Client) GetIssueWithChangelog(issueKey string) (*Issue, error) {
-	return c.GetChangelog(issueKey)
+func (c *Client) GetIssueWithChangelog(ctx context.Context, issueKey string) (*Issue, error) {
+	return c.GetChangelog(ctx, issueKey)
 }
 
 // GetUserActivity retrieves user activity (viewed, created, updated issues)
-func (c *Client) GetUserActivity(userName string) (*SearchResults, *SearchResults, *SearchResults, error) {
+func (c *Client) GetUserActivity(ctx context.Context, userName string) (*SearchResults, *SearchResults, *SearchResults, error) {
 	// Get current user if no userName provided
 	var userAccountID string
 	if userName == "" {
-		user, err := c.GetCurrentUser()
+		user, err := c.GetCurrentUser(ctx)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to get current user: %v", err)
 		}
 		userAccountID = user.AccountID
 	} else {
 		// Search for user by name
-		users, err := c.SearchUsers(userName)
+		users, err := c.SearchUsers(ctx, userName)
 		if err != nil || len(users) == 0 {
 			return nil, nil, nil, fmt.Errorf("user not found: %s", userName)
 		}
@@ -252,19 +253,19 @@ func (c *Client) GetUserActivity(userName string) (*SearchResults, *SearchResult
 	}
 
 	// Get viewed issues (recently updated)
-	viewed, err := c.GetUserActivityJQL(userAccountID, 10)
+	viewed, err := c.GetUserActivityJQL(ctx, userAccountID, 10)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to get viewed issues: %v", err)
 	}
 
 	// Get created issues
-	created, err := c.GetUserModifiedIssues(userAccountID, 10)
+	created, err := c.GetUserModifiedIssues(ctx, userAccountID, 10)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to get created issues: %v", err)
 	}
 
 	// Get updated issues (same as modified for now)
-	updated, err := c.GetUserModifiedIssues(userAccountID, 10)
+	updated, err := c.GetUserModifiedIssues(ctx, userAccountID, 10)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to get updated issues: %v", err)
 	}
@@ -273,63 +274,63 @@ func (c *Client) GetUserActivity(userName string) (*SearchResults, *SearchResult
 }
 
 // SearchIssues searches issues using JQL with default parameters
-func (c *Client) SearchIssues(jql string) (*SearchResults, error) {
-	return c.SearchJQL(jql, 10) // Default to 10 results
+func (c *Client) SearchIssues(ctx context.Context, jql string) (*SearchResults, error) {
+	return c.SearchJQL(ctx, jql, 10) // Default to 10 results
 }
 
 // GetUserViewedIssues gets issues recently viewed by a user
-func (c *Client) GetUserViewedIssues(username string) (*SearchResults, error) {
+func (c *Client) GetUserViewedIssues(ctx context.Context, username string) (*SearchResults, error) {
 	// Search for issues recently updated where user is assignee, reporter, or watcher
 	jql := fmt.Sprintf("updated >= -7d AND (assignee = \"%s\" OR reporter = \"%s\" OR watcher = \"%s\") ORDER BY updated DESC",
 		username, username, username)
-	return c.SearchJQL(jql, 10)
+	return c.SearchJQL(ctx, jql, 10)
 }
 
 // GetUserCreatedIssues gets issues created by a user
-func (c *Client) GetUserCreatedIssues(username string) (*SearchResults, error) {
+func (c *Client) GetUserCreatedIssues(ctx context.Context, username string) (*SearchResults, error) {
 	// Search for issues created by user in the last 7 days
 	jql := fmt.Sprintf("created >= -7d AND reporter = \"%s\" ORDER BY created DESC", username)
-	return c.SearchJQL(jql, 10)
+	return c.SearchJQL(ctx, jql, 10)
 }
 
 // GetUserUpdatedIssues gets issues updated by a user
-func (c *Client) GetUserUpdatedIssues(username string) (*SearchResults, error) {
+func (c *Client) GetUserUpdatedIssues(ctx context.Context, username string) (*SearchResults, error) {
 	// Search for issues updated by user in the last 7 days
 	jql := fmt.Sprintf("updated >= -7d AND (assignee = \"%s\" OR reporter = \"%s\") ORDER BY updated DESC",
 		username, username)
-	return c.SearchJQL(jql, 10)
+	return c.SearchJQL(ctx, jql, 10)
 }
 
 // CreateIssue creates a new issue
-func (c *Client) CreateIssue(summary, description, issueType string) (*Issue, error) {
+func (c *Client) CreateIssue(ctx context.Context, summary, description, issueType string) (*Issue, error) {
 	// This is a placeholder implementation
 	// In a real implementation, you'd make a POST request to create an issue
 	return nil, fmt.Errorf("CreateIssue not implemented")
 }
 
 // UpdateIssue updates an existing issue
-func (c *Client) UpdateIssue(issueKey, summary, description string) (*Issue, error) {
+func (c *Client) UpdateIssue(ctx context.Context, issueKey, summary, description string) (*Issue, error) {
 	// This is a placeholder implementation
 	// In a real implementation, you'd make a PUT request to update an issue
 	return nil, fmt.Errorf("UpdateIssue not implemented")
 }
 
 // AddComment adds a comment to an issue
-func (c *Client) AddComment(issueKey, comment string) error {
+func (c *Client) AddComment(ctx context.Context, issueKey, comment string) error {
 	// This is a placeholder implementation
 	// In a real implementation, you'd make a POST request to add a comment
 	return fmt.Errorf("AddComment not implemented")
 }
 
 // AssignIssue assigns an issue to a user
-func (c *Client) AssignIssue(issueKey, assignee string) error {
+func (c *Client) AssignIssue(ctx context.Context, issueKey, assignee string) error {
 	// This is a placeholder implementation
 	// In a real implementation, you'd make a PUT request to assign an issue
 	return fmt.Errorf("AssignIssue not implemented")
 }
 
 // TransitionIssue transitions an issue to a new status
-func (c *Client) TransitionIssue(issueKey, transition string) error {
+func (c *Client) TransitionIssue(ctx context.Context, issueKey, transition string) error {
 	// This is a placeholder implementation
 	// In a real implementation, you'd make a POST request to transition an issue
 	return fmt.Errorf("TransitionIssue not implemented")
@@ -88,7 +88,7 @@ func FormatIssueDisplay(issue *Issue, showComments, showTesting bool, baseURL st
 
 	// Footer with divider and link
 	output.WriteString("\n----\n")
-	url := getIssueURL(baseURL, issue.Key)
+	url := IssueURL(baseURL, issue.Key)
 	output.WriteString(io.FormatWithEmoji(url, "url"))
 
 	return output.String(), nil
@@ -173,7 +173,7 @@ func FormatIssueOpenMode(issue *Issue, baseURL string) (string, error) {
 
 	// Footer with divider and link
 	output.WriteString("----\n")
-	url := getIssueURL(baseURL, issue.Key)
+	url := IssueURL(baseURL, issue.Key)
 	output.WriteString(io.FormatWithEmoji(url, "url"))
 
 	return output.String(), nil
@@ -181,7 +181,7 @@ func FormatIssueOpenMode(issue *Issue, baseURL string) (string, error) {
 
 // AddFooter adds a footer with issue link
 func AddFooter(issueKey, baseURL string) string {
-	url := getIssueURL(baseURL, issueKey)
+	url := IssueURL(baseURL, issueKey)
 	return fmt.Sprintf("\n\n--------\n%s", io.FormatWithEmoji(url, "url"))
 }
 
@@ -230,8 +230,8 @@ func formatTestingInstructions(issue *Issue) string {
 	return "No specific testing instructions found."
 }
 
-// getIssueURL constructs the full URL for an issue
-func getIssueURL(baseURL, issueKey string) string {
+// IssueURL constructs the full URL for an issue
+func IssueURL(baseURL, issueKey string) string {
 	return fmt.Sprintf("%s/browse/%s", baseURL, issueKey)
 }
 
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/config"
 	"os/exec"
@@ -9,7 +10,7 @@ import (
 )
 
 // handleSearch handles search command
-func handleSearch(args []string, client *Client, flags *Flags) JiraResult {
+func handleSearch(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "search",
@@ -25,7 +26,7 @@ func handleSearch(args []string, client *Client, flags *Flags) JiraResult {
 	}
 
 	// Search issues
-	results, err := client.SearchIssues(jql)
+	results, err := client.SearchIssues(ctx, jql)
 	if err != nil {
 		return JiraResult{
 			Action:  "search",
@@ -48,7 +49,7 @@ func handleSearch(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleUserActivity handles user activity command
-func handleUserActivity(args []string, client *Client, flags *Flags) JiraResult {
+func handleUserActivity(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "user_activity",
@@ -83,7 +84,7 @@ func handleUserActivity(args []string, client *Client, flags *Flags) JiraResult
 	}
 
 	// Get user activity
-	viewedResults, err := client.GetUserViewedIssues(username)
+	viewedResults, err := client.GetUserViewedIssues(ctx, username)
 	if err != nil {
 		return JiraResult{
 			Action:  "user_activity",
@@ -92,7 +93,7 @@ func handleUserActivity(args []string, client *Client, flags *Flags) JiraResult
 		}
 	}
 
-	createdResults, err := client.GetUserCreatedIssues(username)
+	createdResults, err := client.GetUserCreatedIssues(ctx, username)
 	if err != nil {
 		return JiraResult{
 			Action:  "user_activity",
@@ -101,7 +102,7 @@ func handleUserActivity(args []string, client *Client, flags *Flags) JiraResult
 		}
 	}
 
-	updatedResults, err := client.GetUserUpdatedIssues(username)
+	updatedResults, err := client.GetUserUpdatedIssues(ctx, username)
 	if err != nil {
 		return JiraResult{
 			Action:  "user_activity",
@@ -137,7 +138,7 @@ func handleUserActivity(args []string, client *Client, flags *Flags) JiraResult
 }
 
 // handleIssue handles issue lookup
-func handleIssue(client *Client, issueKey, flag string, flags *Flags) JiraResult {
+func handleIssue(ctx context.Context, client *Client, issueKey, flag string, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "issue",
@@ -150,7 +151,7 @@ func handleIssue(client *Client, issueKey, flag string, flags *Flags) JiraResult
 	openInBrowserAfter := flag == "o" || flags.Open
 	if openInBrowserAfter {
 		// Fetch basic issue info
-		issue, err := client.GetIssue(issueKey)
+		issue, err := client.GetIssue(ctx, issueKey)
 		if err != nil {
 			return JiraResult{
 				Action:  "issue",
@@ -189,7 +190,7 @@ func handleIssue(client *Client, issueKey, flag string, flags *Flags) JiraResult
 	}
 
 	// Fetch issue with comments
-	issue, err := client.GetIssueWithComments(issueKey)
+	issue, err := client.GetIssueWithComments(ctx, issueKey)
 	if err != nil {
 		return JiraResult{
 			Action:  "issue",
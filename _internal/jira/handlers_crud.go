@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/config"
 	"os/exec"
@@ -9,7 +10,7 @@ import (
 )
 
 // handleHistory handles history command
-func handleHistory(args []string, client *Client, flags *Flags) JiraResult {
+func handleHistory(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "history",
@@ -31,7 +32,7 @@ func handleHistory(args []string, client *Client, flags *Flags) JiraResult {
 	}
 
 	// Fetch issue with changelog
-	issue, err := client.GetIssueWithChangelog(issueKey)
+	issue, err := client.GetIssueWithChangelog(ctx, issueKey)
 	if err != nil {
 		return JiraResult{
 			Action:  "history",
@@ -58,7 +59,7 @@ func handleHistory(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleCreate handles create command
-func handleCreate(args []string, client *Client, flags *Flags) JiraResult {
+func handleCreate(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "create",
@@ -84,7 +85,7 @@ func handleCreate(args []string, client *Client, flags *Flags) JiraResult {
 	}
 
 	// Create issue
-	issue, err := client.CreateIssue(project, summary, description)
+	issue, err := client.CreateIssue(ctx, project, summary, description)
 	if err != nil {
 		return JiraResult{
 			Action:  "create",
@@ -110,7 +111,7 @@ func handleCreate(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleUpdate handles update command
-func handleUpdate(args []string, client *Client, flags *Flags) JiraResult {
+func handleUpdate(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "update",
@@ -133,7 +134,7 @@ func handleUpdate(args []string, client *Client, flags *Flags) JiraResult {
 	value := strings.Join(args[2:], " ")
 
 	// Update issue
-	_, err := client.UpdateIssue(issueKey, field, value)
+	_, err := client.UpdateIssue(ctx, issueKey, field, value)
 	if err != nil {
 		return JiraResult{
 			Action:  "update",
@@ -151,7 +152,7 @@ func handleUpdate(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleComment handles comment command
-func handleComment(args []string, client *Client, flags *Flags) JiraResult {
+func handleComment(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "comment",
@@ -173,7 +174,7 @@ func handleComment(args []string, client *Client, flags *Flags) JiraResult {
 	comment := strings.Join(args[1:], " ")
 
 	// Add comment
-	err := client.AddComment(issueKey, comment)
+	err := client.AddComment(ctx, issueKey, comment)
 	if err != nil {
 		return JiraResult{
 			Action:  "comment",
@@ -191,7 +192,7 @@ func handleComment(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleAssign handles assign command
-func handleAssign(args []string, client *Client, flags *Flags) JiraResult {
+func handleAssign(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "assign",
@@ -213,7 +214,7 @@ func handleAssign(args []string, client *Client, flags *Flags) JiraResult {
 	assignee := args[1]
 
 	// Assign issue
-	err := client.AssignIssue(issueKey, assignee)
+	err := client.AssignIssue(ctx, issueKey, assignee)
 	if err != nil {
 		return JiraResult{
 			Action:  "assign",
@@ -231,7 +232,7 @@ func handleAssign(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleTransition handles transition command
-func handleTransition(args []string, client *Client, flags *Flags) JiraResult {
+func handleTransition(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "transition",
@@ -253,7 +254,7 @@ func handleTransition(args []string, client *Client, flags *Flags) JiraResult {
 	transition := args[1]
 
 	// Transition issue
-	err := client.TransitionIssue(issueKey, transition)
+	err := client.TransitionIssue(ctx, issueKey, transition)
 	if err != nil {
 		return JiraResult{
 			Action:  "transition",
@@ -271,7 +272,7 @@ func handleTransition(args []string, client *Client, flags *Flags) JiraResult {
 }
 
 // handleOpen handles open command
-func handleOpen(args []string, client *Client, flags *Flags) JiraResult {
+func handleOpen(ctx context.Context, args []string, client *Client, flags *Flags) JiraResult {
 	if client == nil {
 		return JiraResult{
 			Action:  "open",
@@ -11,6 +11,9 @@ type Issue struct {
 		Status      struct {
 			Name string `json:"name"`
 		} `json:"status"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
 		Assignee struct {
 			DisplayName string `json:"displayName"`
 			Email       string `json:"emailAddress"`
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"context"
 	"fmt"
 	"cli-go/_internal/ai"
 	"cli-go/_internal/io"
@@ -25,12 +26,12 @@ type JiraResult struct {
 }
 
 // RouteCommand routes commands to appropriate handlers
-func RouteCommand(command string, args []string, client *Client, flags *Flags) JiraResult {
+func RouteCommand(ctx context.Context, command string, args []string, client *Client, flags *Flags) JiraResult {
 	switch command {
 	case "history":
-		return handleHistory(args, client, flags)
+		return handleHistory(ctx, args, client, flags)
 	case "u", "user":
-		return handleUserActivity(args, client, flags)
+		return handleUserActivity(ctx, args, client, flags)
 	case "help":
 		return handleHelp(flags)
 	default:
@@ -40,7 +41,7 @@ func RouteCommand(command string, args []string, client *Client, flags *Flags) J
 		if len(args) > 0 {
 			flag = args[0]
 		}
-		return handleIssue(client, command, flag, flags)
+		return handleIssue(ctx, client, command, flag, flags)
 	}
 }
 
@@ -0,0 +1,130 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"cli-go/_internal/jira"
+)
+
+// action runs a non-AI step; message is the rendered step input, args are already rendered and
+// env holds the pipeline input and earlier outputs as environment variables (see stepEnv)
+type action func(ctx context.Context, step Step, message string, args map[string]string, env []string) (string, error)
+
+// actions maps action names to their implementation
+var actions = map[string]action{
+	"shell":      shellAction,
+	"git-diff":   gitDiffAction,
+	"jira-issue": jiraIssueAction,
+	"read-file":  readFileAction,
+}
+
+// actionNames returns the supported action names sorted
+func actionNames() []string {
+	var names []string
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runAction renders the step's args, then runs its action. The shell command is never rendered:
+// outputs can come from AI models or Jira, so they only reach the shell through env.
+func runAction(ctx context.Context, step Step, message, input string, outputs map[string]string) (string, error) {
+	args := map[string]string{}
+	for key, value := range step.Args {
+		args[key] = render(value, input, outputs)
+	}
+	return actions[step.Action](ctx, step, message, args, stepEnv(input, outputs))
+}
+
+// shellAction runs `run` with sh -c, feeding the step input on stdin and the pipeline input and
+// earlier outputs as PIPELINE_INPUT and STEP_<ID>_OUTPUT
+func shellAction(ctx context.Context, step Step, message string, args map[string]string, env []string) (string, error) {
+	if step.Run == "" {
+		return "", fmt.Errorf("shell action requires run")
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", step.Run)
+	cmd.WaitDelay = time.Second // don't wait for orphaned children holding the pipes after cancel
+	cmd.Stdin = strings.NewReader(message)
+	cmd.Env = append(os.Environ(), env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitDiffAction returns the working tree diff, the staged diff (staged: true)
+// or the diff against a base branch (base: main), optionally limited to path
+func gitDiffAction(ctx context.Context, step Step, message string, args map[string]string, env []string) (string, error) {
+	gitArgs := []string{"diff"}
+	switch {
+	case args["base"] != "":
+		gitArgs = append(gitArgs, args["base"]+"...HEAD")
+	case args["staged"] == "true":
+		gitArgs = append(gitArgs, "--cached")
+	}
+	if args["path"] != "" {
+		gitArgs = append(gitArgs, "--", args["path"])
+	}
+
+	output, err := exec.CommandContext(ctx, "git", gitArgs...).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return "", fmt.Errorf("git diff failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	if err != nil {
+		return "", fmt.Errorf("git diff failed: %v", err)
+	}
+	diff := strings.TrimSpace(string(output))
+	if diff == "" {
+		return "", fmt.Errorf("git diff is empty")
+	}
+	return diff, nil
+}
+
+// jiraIssueAction fetches an issue with comments as markdown (key defaults to the step input)
+func jiraIssueAction(ctx context.Context, step Step, message string, args map[string]string, env []string) (string, error) {
+	key := strings.TrimSpace(args["key"])
+	if key == "" {
+		key = strings.TrimSpace(message)
+	}
+	if key == "" {
+		return "", fmt.Errorf("jira-issue action requires args.key")
+	}
+
+	jiraConfig, apiToken, err := jira.LoadJiraConfig()
+	if err != nil {
+		return "", err
+	}
+	client := jira.NewClient(jiraConfig.BaseURL, jiraConfig.Email, apiToken, jiraConfig.DefaultProject)
+
+	issue, err := client.GetIssueWithComments(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch issue: %v", err)
+	}
+	return jira.FormatIssueDisplay(issue, true, true, client.BaseURL)
+}
+
+// readFileAction returns the content of args.path
+func readFileAction(ctx context.Context, step Step, message string, args map[string]string, env []string) (string, error) {
+	if args["path"] == "" {
+		return "", fmt.Errorf("read-file action requires args.path")
+	}
+	data, err := os.ReadFile(args["path"])
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %v", err)
+	}
+	return string(data), nil
+}
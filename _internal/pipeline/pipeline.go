@@ -0,0 +1,179 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"cli-go/_internal/ai"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pipeline is an ordered list of prompt and action steps loaded from YAML
+type Pipeline struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Provider    string `yaml:"provider" json:"provider,omitempty"` // default provider for prompt steps
+	Model       string `yaml:"model" json:"model,omitempty"`       // default model for prompt steps
+	Steps       []Step `yaml:"steps" json:"steps"`
+
+	Path string `yaml:"-" json:"path"`
+}
+
+// Step is either an AI prompt step or an action (shell, git-diff, jira-issue, read-file)
+type Step struct {
+	ID       string            `yaml:"id" json:"id"`
+	Prompt   string            `yaml:"prompt" json:"prompt,omitempty"`     // prompt name or ID used as system prompt
+	Persona  string            `yaml:"persona" json:"persona,omitempty"`   // persona from config
+	Provider string            `yaml:"provider" json:"provider,omitempty"` // overrides the pipeline provider
+	Model    string            `yaml:"model" json:"model,omitempty"`       // overrides the pipeline model
+	Input    string            `yaml:"input" json:"input,omitempty"`       // message template, defaults to the previous output
+	Action   string            `yaml:"action" json:"action,omitempty"`
+	Run      string            `yaml:"run" json:"run,omitempty"` // shell command for action: shell
+	Args     map[string]string `yaml:"args" json:"args,omitempty"`
+}
+
+// templateRef matches {{input}} and {{steps.<id>.output}} references
+var templateRef = regexp.MustCompile(`\{\{\s*(input|steps\.([A-Za-z0-9_-]+)\.output)\s*\}\}`)
+
+// Dir returns the pipelines directory inside the prompts directory
+func Dir() string {
+	return filepath.Join(ai.NewPromptClient().BaseDir(), "pipelines")
+}
+
+// List loads every pipeline in the pipelines directory sorted by name
+func List() ([]*Pipeline, error) {
+	files, err := filepath.Glob(filepath.Join(Dir(), "*.y*ml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipelines: %v", err)
+	}
+
+	var pipelines []*Pipeline
+	for _, file := range files {
+		p, err := loadFile(file)
+		if err != nil {
+			ai.LogError("Skipping pipeline %s: %v", file, err)
+			continue
+		}
+		pipelines = append(pipelines, p)
+	}
+
+	sort.Slice(pipelines, func(i, j int) bool { return pipelines[i].Name < pipelines[j].Name })
+	return pipelines, nil
+}
+
+// Load finds a pipeline by file path or name in the pipelines directory
+func Load(nameOrPath string) (*Pipeline, error) {
+	if info, err := os.Stat(nameOrPath); err == nil && !info.IsDir() {
+		return loadFile(nameOrPath)
+	}
+
+	for _, ext := range []string{".yml", ".yaml"} {
+		path := filepath.Join(Dir(), strings.TrimSuffix(nameOrPath, filepath.Ext(nameOrPath))+ext)
+		if _, err := os.Stat(path); err == nil {
+			return loadFile(path)
+		}
+	}
+
+	return nil, fmt.Errorf("pipeline not found: %s (run 'pipeline list')", nameOrPath)
+}
+
+// loadFile parses and validates a pipeline file
+func loadFile(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline: %v", err)
+	}
+
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid pipeline YAML: %v", err)
+	}
+	p.Path = path
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// validate assigns default step IDs and checks that references only point to earlier steps
+func (p *Pipeline) validate() error {
+	if len(p.Steps) == 0 {
+		return fmt.Errorf("pipeline %s has no steps", p.Name)
+	}
+
+	seen := map[string]bool{}
+	for i := range p.Steps {
+		step := &p.Steps[i]
+		if step.ID == "" {
+			step.ID = fmt.Sprintf("step%d", i+1)
+		}
+		if seen[step.ID] {
+			return fmt.Errorf("step %d: duplicate id %q", i+1, step.ID)
+		}
+
+		if step.Run != "" && step.Action == "" {
+			step.Action = "shell"
+		}
+		if step.Action != "" {
+			if _, ok := actions[step.Action]; !ok {
+				return fmt.Errorf("step %s: unknown action %q (use %s)", step.ID, step.Action, strings.Join(actionNames(), ", "))
+			}
+			if step.Prompt != "" || step.Persona != "" {
+				return fmt.Errorf("step %s: an action step cannot also have a prompt or persona", step.ID)
+			}
+		}
+
+		if templateRef.MatchString(step.Run) {
+			return fmt.Errorf("step %s: run cannot use {{...}} templates, read \"$PIPELINE_INPUT\" or \"$%s\" instead", step.ID, stepEnvName("<id>"))
+		}
+
+		templates := []string{step.Input}
+		for _, value := range step.Args {
+			templates = append(templates, value)
+		}
+		for _, tmpl := range templates {
+			for _, ref := range templateRef.FindAllStringSubmatch(tmpl, -1) {
+				if ref[2] != "" && !seen[ref[2]] {
+					return fmt.Errorf("step %s: references %q, which is not an earlier step", step.ID, ref[2])
+				}
+			}
+		}
+
+		seen[step.ID] = true
+	}
+	return nil
+}
+
+// stepEnv returns the pipeline input and the step outputs as environment variables for shell steps
+func stepEnv(input string, outputs map[string]string) []string {
+	env := []string{"PIPELINE_INPUT=" + input}
+	for id, output := range outputs {
+		env = append(env, stepEnvName(id)+"="+output)
+	}
+	return env
+}
+
+// stepEnvName returns the variable holding a step's output: STEP_<ID>_OUTPUT, with - as _
+func stepEnvName(id string) string {
+	return "STEP_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_OUTPUT"
+}
+
+// render replaces {{input}} and {{steps.<id>.output}} in a template
+func render(tmpl, input string, outputs map[string]string) string {
+	return templateRef.ReplaceAllStringFunc(tmpl, func(match string) string {
+		ref := templateRef.FindStringSubmatch(match)
+		if ref[2] == "" {
+			return input
+		}
+		return outputs[ref[2]]
+	})
+}
@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-go/_internal/ai"
+)
+
+// StepResult is the output and timing of one executed step
+type StepResult struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"` // "prompt" or the action name
+	Model      string `json:"model,omitempty"`
+	Output     string `json:"output"`
+	Error      string `json:"error,omitempty"`
+	ErrorKind  string `json:"error_kind,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// RunOptions controls how prompt steps talk to providers
+type RunOptions struct {
+	Generation *ai.GenerationFlags // generation flags from the command line, may be nil
+	NoRedact   bool
+}
+
+// Run executes the steps in order, stopping at the first failure or when ctx is cancelled.
+// onStep is called after each step with its result.
+func Run(ctx context.Context, p *Pipeline, input string, opts RunOptions, onStep func(StepResult)) ([]StepResult, error) {
+	outputs := map[string]string{}
+	previous := input
+	var results []StepResult
+
+	for _, step := range p.Steps {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		message := previous
+		if step.Input != "" {
+			message = render(step.Input, input, outputs)
+		}
+
+		start := time.Now()
+		result := StepResult{ID: step.ID, Kind: step.Action}
+		var err error
+		if step.Action != "" {
+			result.Output, err = runAction(ctx, step, message, input, outputs)
+		} else {
+			result.Kind = "prompt"
+			result.Output, result.Model, err = runPrompt(ctx, p, step, message, opts)
+		}
+		result.DurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			result.Error = err.Error()
+			if apiErr, ok := ai.AsAPIError(err); ok {
+				result.ErrorKind = string(apiErr.Kind)
+			}
+		}
+
+		results = append(results, result)
+		if onStep != nil {
+			onStep(result)
+		}
+		if ctx.Err() != nil {
+			return results, fmt.Errorf("step %s cancelled: %w", step.ID, ctx.Err())
+		}
+		if err != nil {
+			return results, fmt.Errorf("step %s failed: %w", step.ID, err)
+		}
+
+		outputs[step.ID] = result.Output
+		previous = result.Output
+	}
+
+	return results, nil
+}
+
+// runPrompt sends the message to the step's provider with its prompt as system prompt
+func runPrompt(ctx context.Context, p *Pipeline, step Step, message string, opts RunOptions) (string, string, error) {
+	persona, err := ai.ResolvePersona("pipeline", step.Persona)
+	if err != nil {
+		return "", "", err
+	}
+	if persona == nil {
+		persona = &ai.Persona{}
+	}
+
+	if step.Prompt != "" {
+		promptClient := ai.NewPromptClient()
+		promptFile, err := promptClient.Resolve(step.Prompt)
+		if err != nil {
+			return "", "", err
+		}
+		content, err := promptClient.LoadPrompt(promptFile)
+		if err != nil {
+			return "", "", err
+		}
+		persona.SystemPrompt = content
+	}
+
+	if model := firstNonEmpty(step.Model, p.Model); model != "" {
+		persona.Model = model
+	}
+
+	client, err := ai.NewProviderClient(firstNonEmpty(step.Provider, p.Provider))
+	if err != nil {
+		return "", "", err
+	}
+	client.SetPersona(persona)
+	client.SetRedact(!opts.NoRedact)
+	if opts.Generation != nil {
+		params, err := opts.Generation.Resolve("pipeline", persona)
+		if err != nil {
+			return "", "", err
+		}
+		client.SetParams(params)
+	}
+
+	response, err := client.SendMessage(ctx, message)
+	return response, client.GetModel(), err
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
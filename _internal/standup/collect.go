@@ -0,0 +1,273 @@
+package standup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cli-go/_internal/config"
+	"cli-go/_internal/custom"
+	"cli-go/_internal/git"
+	"cli-go/_internal/github"
+	"cli-go/_internal/io"
+	"cli-go/_internal/jira"
+)
+
+// Report is the standup report (also the --json output)
+type Report struct {
+	Since     time.Time        `json:"since"`
+	Yesterday []TicketActivity `json:"yesterday"`
+	Untracked []git.Commit     `json:"untracked"` // commits without a ticket
+	Today     []Item           `json:"today"`
+	Blockers  []Item           `json:"blockers"`
+	Summary   string           `json:"summary,omitempty"`
+	Warnings  []string         `json:"warnings,omitempty"`
+}
+
+// TicketActivity is everything done on one ticket in the window
+type TicketActivity struct {
+	Key     string       `json:"key"`
+	Summary string       `json:"summary,omitempty"`
+	Status  string       `json:"status,omitempty"`
+	URL     string       `json:"url,omitempty"`
+	Jira    bool         `json:"jira"` // transitioned, commented or updated in Jira
+	Commits []git.Commit `json:"commits,omitempty"`
+	PRs     []github.PR  `json:"prs,omitempty"`
+}
+
+// Item is a planned or blocked piece of work
+type Item struct {
+	Key    string `json:"key,omitempty"`
+	Title  string `json:"title"`
+	Status string `json:"status,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Options selects the window and the sources of a report
+type Options struct {
+	Repos    []config.RepoConfig
+	Author   string // commit author; empty uses each repository's git user.email
+	Since    time.Time
+	NoGitHub bool
+	NoJira   bool
+}
+
+// ticketSet collects activity per ticket key
+type ticketSet map[string]*TicketActivity
+
+// get returns the activity for a ticket, creating it on first use
+func (t ticketSet) get(key string) *TicketActivity {
+	if t[key] == nil {
+		t[key] = &TicketActivity{Key: key}
+	}
+	return t[key]
+}
+
+// Collect builds a report from commits, PRs and Jira. A failing source only adds a warning;
+// the returned error is the context's when the collection was cancelled.
+func Collect(ctx context.Context, opts Options) (*Report, error) {
+	report := &Report{Since: opts.Since}
+	tickets := ticketSet{}
+
+	if err := collectCommits(ctx, report, opts, tickets); err != nil {
+		return nil, err
+	}
+	if !opts.NoGitHub {
+		if err := collectPRs(ctx, report, opts, tickets); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.NoJira {
+		if err := collectJira(ctx, report, tickets); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, activity := range tickets {
+		report.Yesterday = append(report.Yesterday, *activity)
+	}
+	sort.Slice(report.Yesterday, func(i, j int) bool { return report.Yesterday[i].Key < report.Yesterday[j].Key })
+	return report, nil
+}
+
+// collectCommits adds the author's commits from all repos, grouped by ticket
+func collectCommits(ctx context.Context, report *Report, opts Options, tickets ticketSet) error {
+	var paths []string
+	for _, repo := range opts.Repos {
+		paths = append(paths, repo.Path)
+	}
+
+	results := git.RunParallel(ctx, paths, git.PoolOptions{Label: "Reading commits"}, func(ctx context.Context, path string) ([]git.Commit, error) {
+		author := opts.Author
+		if author == "" {
+			email, err := git.GetUserEmail(path)
+			if err != nil {
+				return nil, err
+			}
+			author = email
+		}
+		return git.GetCommits(ctx, path, git.LogOptions{All: true, NoMerges: true, Since: opts.Since, Author: author})
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			report.warn("Failed to get commits from %s: %v", result.Repo, result.Err)
+		}
+		for _, commit := range result.Value {
+			if len(commit.TicketIDs) == 0 {
+				report.Untracked = append(report.Untracked, commit)
+				continue
+			}
+			for _, key := range commit.TicketIDs {
+				tickets.get(key).Commits = append(tickets.get(key).Commits, commit)
+			}
+		}
+	}
+	return nil
+}
+
+// collectPRs adds my PRs updated in the window; open ones are also today's work
+func collectPRs(ctx context.Context, report *Report, opts Options, tickets ticketSet) error {
+	query := fmt.Sprintf("author:@me updated:>=%s", opts.Since.Format("2006-01-02"))
+	results := git.RunParallel(ctx, github.RepoSlugs(opts.Repos), git.PoolOptions{Label: "Fetching PRs"}, func(ctx context.Context, slug string) ([]github.PR, error) {
+		owner, repo := github.SplitSlug(slug)
+		return github.SearchPRsByQuery(ctx, owner, repo, query)
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			report.warn("Failed to get PRs from %s: %v", result.Repo, result.Err)
+			continue
+		}
+		for _, pr := range result.Value {
+			if keys := custom.ExtractTicketsFromMessage(pr.Title + " " + pr.HeadRefName); len(keys) > 0 {
+				pr.TicketID = keys[0]
+			}
+			pr.Body = "" // keep reports and AI input short
+
+			if pr.UpdatedAt.After(opts.Since) && pr.TicketID != "" {
+				tickets.get(pr.TicketID).PRs = append(tickets.get(pr.TicketID).PRs, pr)
+			}
+			if pr.State == "OPEN" {
+				status := "awaiting review"
+				if pr.IsDraft {
+					status = "draft"
+				}
+				report.addToday(Item{
+					Key:    pr.TicketID,
+					Title:  fmt.Sprintf("PR #%d %s (%s)", pr.Number, pr.Title, pr.Repo),
+					Status: status,
+					URL:    pr.URL,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// collectJira adds issues I transitioned, commented on or updated, ticket details,
+// my in-progress issues (today) and my blocked or flagged issues (blockers)
+func collectJira(ctx context.Context, report *Report, tickets ticketSet) error {
+	jiraConfig, apiToken, err := jira.LoadJiraConfig()
+	if err != nil {
+		report.warn("Skipping Jira: %v", err)
+		return nil
+	}
+	client := jira.NewClient(jiraConfig.BaseURL, jiraConfig.Email, apiToken, jiraConfig.DefaultProject)
+
+	// Comments count as updates, so updatedBy covers both edits and comments
+	date := report.Since.Format("2006-01-02 15:04")
+	touched, err := client.SearchJQL(ctx, fmt.Sprintf(
+		`status CHANGED BY currentUser() AFTER "%s" OR issuekey IN updatedBy(currentUser(), "%s") ORDER BY updated DESC`, date, date), 50)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		report.warn("Failed to get Jira activity: %v", err)
+	} else {
+		for _, issue := range touched.Issues {
+			activity := tickets.get(issue.Key)
+			activity.Jira = true
+			activity.Summary = issue.Fields.Summary
+			activity.Status = issue.Fields.Status.Name
+		}
+	}
+
+	// Tickets only seen in commits or PRs; keys that aren't issues (e.g. UTF-8) are skipped quietly
+	for key, activity := range tickets {
+		activity.URL = jira.IssueURL(jiraConfig.BaseURL, key)
+		if activity.Summary != "" {
+			continue
+		}
+		issue, err := client.GetIssue(ctx, key)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			activity.URL = ""
+			continue
+		}
+		activity.Summary = issue.Fields.Summary
+		activity.Status = issue.Fields.Status.Name
+	}
+
+	inProgress, err := client.SearchJQL(ctx, `assignee = currentUser() AND statusCategory = "In Progress" ORDER BY updated DESC`, 20)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		report.warn("Failed to get in-progress issues: %v", err)
+	} else {
+		for _, issue := range inProgress.Issues {
+			report.addToday(issueItem(issue, jiraConfig.BaseURL))
+		}
+	}
+
+	blocked, err := client.SearchJQL(ctx, `assignee = currentUser() AND statusCategory != Done AND (status = "Blocked" OR Flagged is not EMPTY) ORDER BY updated DESC`, 20)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		report.warn("Failed to get blocked issues: %v", err)
+	} else {
+		for _, issue := range blocked.Issues {
+			report.Blockers = append(report.Blockers, issueItem(issue, jiraConfig.BaseURL))
+		}
+	}
+	return nil
+}
+
+// issueItem converts a Jira issue to a report item
+func issueItem(issue jira.Issue, baseURL string) Item {
+	return Item{
+		Key:    issue.Key,
+		Title:  issue.Fields.Summary,
+		Status: issue.Fields.Status.Name,
+		URL:    jira.IssueURL(baseURL, issue.Key),
+	}
+}
+
+// addToday adds an item to Today unless its ticket is already there (an open PR and the
+// in-progress issue are the same piece of work); items without a ticket are always added
+func (r *Report) addToday(item Item) {
+	for _, existing := range r.Today {
+		if item.Key != "" && existing.Key == item.Key {
+			return
+		}
+	}
+	r.Today = append(r.Today, item)
+}
+
+// warn logs a warning on stderr and keeps it for the JSON output
+func (r *Report) warn(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	io.LogWarning("%s", message)
+	r.Warnings = append(r.Warnings, message)
+}
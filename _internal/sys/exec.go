@@ -2,10 +2,13 @@ package sys
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"syscall"
+	"time"
 )
 
 // ExecResult holds the result of a command execution
@@ -40,11 +43,39 @@ func RunCommand(name string, args ...string) *ExecResult {
 	}
 }
 
+// RunCommandRaw executes a command like RunCommand but returns stdout untrimmed, for output
+// where whitespace matters such as patches
+func RunCommandRaw(name string, args ...string) *ExecResult {
+	return runCommand(exec.Command(name, args...), false)
+}
+
 // RunCommandInDir executes a command in a specific directory
 func RunCommandInDir(dir, name string, args ...string) *ExecResult {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
+	return runCommand(cmd, true)
+}
+
+// RunCommandInDirContext executes a command in a specific directory and kills it, including the
+// processes it started, when ctx is cancelled or times out, so no command outlives its caller
+func RunCommandInDirContext(ctx context.Context, dir, name string, args ...string) *ExecResult {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
 
+	result := runCommand(cmd, true)
+	if ctx.Err() != nil {
+		result.Error = ctx.Err()
+	}
+	return result
+}
+
+// runCommand runs cmd capturing its output; stderr is always trimmed, stdout when trim is set
+func runCommand(cmd *exec.Cmd, trim bool) *ExecResult {
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -57,8 +88,12 @@ func RunCommandInDir(dir, name string, args ...string) *ExecResult {
 		}
 	}
 
+	output := stdout.String()
+	if trim {
+		output = strings.TrimSpace(output)
+	}
 	return &ExecResult{
-		Stdout:   strings.TrimSpace(stdout.String()),
+		Stdout:   output,
 		Stderr:   strings.TrimSpace(stderr.String()),
 		ExitCode: exitCode,
 		Error:    err,
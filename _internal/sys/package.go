@@ -2,6 +2,7 @@ package sys
 
 import (
 	"os"
+	"path/filepath"
 )
 
 // PackageManager represents a package manager
@@ -16,19 +17,24 @@ const (
 
 // DetectPackageManager detects which package manager to use
 func DetectPackageManager() PackageManager {
+	return DetectPackageManagerIn("")
+}
+
+// DetectPackageManagerIn detects which package manager to use in dir ("" for the current directory)
+func DetectPackageManagerIn(dir string) PackageManager {
 	// Check for lock files in order of preference
-	if _, err := os.Stat("yarn.lock"); err == nil {
+	if _, err := os.Stat(filepath.Join(dir, "yarn.lock")); err == nil {
 		return ManagerYarn
 	}
-	if _, err := os.Stat("pnpm-lock.yaml"); err == nil {
+	if _, err := os.Stat(filepath.Join(dir, "pnpm-lock.yaml")); err == nil {
 		return ManagerPnpm
 	}
-	if _, err := os.Stat("package-lock.json"); err == nil {
+	if _, err := os.Stat(filepath.Join(dir, "package-lock.json")); err == nil {
 		return ManagerNpm
 	}
 
 	// Check for package.json to determine if it's a Node.js project
-	if _, err := os.Stat("package.json"); err == nil {
+	if _, err := os.Stat(filepath.Join(dir, "package.json")); err == nil {
 		return ManagerNpm // Default to npm if package.json exists
 	}
 
@@ -44,7 +50,12 @@ type InstallResult struct {
 
 // InstallPackages installs packages using the detected manager
 func InstallPackages() *InstallResult {
-	manager := DetectPackageManager()
+	return InstallPackagesIn("")
+}
+
+// InstallPackagesIn installs packages in dir ("" for the current directory) using the detected manager
+func InstallPackagesIn(dir string) *InstallResult {
+	manager := DetectPackageManagerIn(dir)
 
 	if manager == ManagerNone {
 		return &InstallResult{
@@ -69,7 +80,7 @@ func InstallPackages() *InstallResult {
 		args = []string{"install", "--legacy-peer-deps"}
 	}
 
-	result := RunCommand(cmd, args...)
+	result := RunCommandInDir(dir, cmd, args...)
 	if result.ExitCode != 0 {
 		return &InstallResult{
 			Installed: false,
@@ -0,0 +1,31 @@
+package sys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// SignalContext returns a context that is cancelled on SIGINT or SIGTERM.
+// After the first signal the default handling is restored, so a second Ctrl-C exits immediately.
+func SignalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			fmt.Fprintln(os.Stderr, "\nInterrupted, cancelling... (press Ctrl-C again to force quit)")
+			cancel()
+		case <-ctx.Done():
+			signal.Stop(signals)
+		}
+	}()
+
+	return ctx, cancel
+}
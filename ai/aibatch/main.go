@@ -0,0 +1,204 @@
+package main
+
+// DESCRIPTION: run a prompt over many inputs (JSONL)
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+)
+
+type ToolConfig struct {
+	Input       string
+	Out         string
+	Provider    string
+	Model       string
+	Prompt      string
+	Persona     string
+	Template    string
+	Concurrency int
+	Rate        int
+	Retries     int
+	JSON        bool
+	NoRedact    bool
+	Generation  *ai.GenerationFlags
+}
+
+// BatchSummary is printed when the run finishes
+type BatchSummary struct {
+	Total     int    `json:"total"`
+	Skipped   int    `json:"skipped"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Output    string `json:"output"`
+	Duration  string `json:"duration"`
+}
+
+func main() {
+	toolConfig := parseFlags()
+
+	// Ctrl-C stops the batch; finished results are kept so the run can be resumed
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	if toolConfig.Input == "" && len(flag.Args()) > 0 {
+		toolConfig.Input = flag.Args()[0]
+	}
+	if toolConfig.Input == "" {
+		ai.LogError("Usage: aibatch <inputs.jsonl> [--out results.jsonl] [--prompt name] [--template '...{{input}}...']")
+		os.Exit(1)
+	}
+
+	items, err := ai.ReadBatchItems(toolConfig.Input)
+	ai.ExitIf(err, "failed to read batch inputs")
+
+	// Resume: skip items that already have a response and drop failed results from the output file
+	pending := items
+	if toolConfig.Out != "" {
+		done, err := ai.ResumeBatchOutput(toolConfig.Out)
+		ai.ExitIf(err, "failed to read previous results")
+		pending = nil
+		for _, item := range items {
+			if !done[item.ID] {
+				pending = append(pending, item)
+			}
+		}
+		if skipped := len(items) - len(pending); skipped > 0 {
+			ai.LogInfo("⏭️  Resuming: %d of %d items already done", skipped, len(items))
+		}
+	}
+
+	persona := buildPersona(toolConfig)
+	params, err := toolConfig.Generation.Resolve("aibatch", persona)
+	ai.ExitIf(err, "invalid generation parameters")
+
+	// The grok CLI shares a .grok work directory, so it cannot run in parallel
+	if isGrok(toolConfig.Provider) && toolConfig.Concurrency > 1 {
+		ai.LogInfo("⚠️  grok CLI does not support parallel requests, using --concurrency 1")
+		toolConfig.Concurrency = 1
+	}
+
+	newClient := func() (ai.ProviderClient, error) {
+		client, err := ai.NewProviderClient(toolConfig.Provider)
+		if err != nil {
+			return nil, err
+		}
+		client.SetPersona(persona)
+		client.SetParams(params)
+		client.SetRedact(!toolConfig.NoRedact)
+		return client, nil
+	}
+
+	// Results go to stdout or are appended to --out as they complete
+	out := os.Stdout
+	if toolConfig.Out != "" {
+		out, err = os.OpenFile(toolConfig.Out, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		ai.ExitIf(err, "failed to open output file")
+		defer out.Close()
+	}
+
+	summary := BatchSummary{Total: len(items), Skipped: len(items) - len(pending), Output: toolConfig.Out}
+	if summary.Output == "" {
+		summary.Output = "stdout"
+	}
+
+	start := time.Now()
+	if len(pending) > 0 {
+		err = ai.RunBatch(ctx, pending, newClient, ai.BatchOptions{
+			Concurrency:   toolConfig.Concurrency,
+			RatePerMinute: toolConfig.Rate,
+			Template:      toolConfig.Template,
+			Retries:       toolConfig.Retries,
+		}, func(result ai.BatchResult) {
+			line, _ := json.Marshal(result)
+			fmt.Fprintln(out, string(line))
+
+			status := "✅"
+			if result.Error != "" {
+				status = "❌"
+				summary.Failed++
+			} else {
+				summary.Succeeded++
+			}
+			done := summary.Skipped + summary.Succeeded + summary.Failed
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s %s (%dms)\n", done, summary.Total, status, result.ID, result.DurationMs)
+		})
+		if ctx.Err() != nil && toolConfig.Out != "" {
+			ai.LogInfo("⏸️  Interrupted after %d results, re-run with the same --out to resume", summary.Succeeded+summary.Failed)
+		}
+		ai.ExitIf(err, "batch failed")
+	}
+	summary.Duration = time.Since(start).Round(time.Millisecond).String()
+
+	if toolConfig.JSON && toolConfig.Out != "" {
+		io.DirectOutput(summary, false, "", true)
+	} else {
+		ai.LogInfo("📦 %d items: %d succeeded, %d failed, %d skipped in %s → %s",
+			summary.Total, summary.Succeeded, summary.Failed, summary.Skipped, summary.Duration, summary.Output)
+	}
+
+	if summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// buildPersona combines --persona, --prompt and --model into one persona
+func buildPersona(toolConfig ToolConfig) *ai.Persona {
+	persona, err := ai.ResolvePersona("aibatch", toolConfig.Persona)
+	ai.ExitIf(err, "failed to resolve persona")
+	if persona == nil {
+		persona = &ai.Persona{}
+	}
+
+	if toolConfig.Prompt != "" {
+		promptClient := ai.NewPromptClient()
+		promptFile, err := promptClient.Resolve(toolConfig.Prompt)
+		ai.ExitIf(err, "failed to resolve prompt")
+		content, err := promptClient.LoadPrompt(promptFile)
+		ai.ExitIf(err, "failed to load prompt")
+		persona.SystemPrompt = content
+	}
+	if toolConfig.Model != "" {
+		persona.Model = toolConfig.Model
+	}
+	return persona
+}
+
+// isGrok reports whether the provider name refers to the grok CLI
+func isGrok(provider string) bool {
+	switch strings.ToLower(provider) {
+	case "xai", "grok", "gro":
+		return true
+	}
+	return false
+}
+
+func parseFlags() ToolConfig {
+	toolConfig := ToolConfig{}
+
+	flag.StringVar(&toolConfig.Input, "batch", "", "JSONL input file ({\"id\",\"input\"} objects or strings)")
+	flag.StringVar(&toolConfig.Out, "out", "", "Append JSONL results to file and resume from it (default: stdout)")
+	flag.StringVar(&toolConfig.Provider, "provider", "openai", "Provider: openai, anthropic, haiku, google, xai")
+	flag.StringVar(&toolConfig.Model, "model", "", "Model override")
+	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Prompt name or ID used as system prompt")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Template, "template", "", "Message template, {{input}} is replaced by each input")
+	flag.IntVar(&toolConfig.Concurrency, "concurrency", 4, "Parallel requests")
+	flag.IntVar(&toolConfig.Rate, "rate", 0, "Maximum requests per minute, retries included (0 = unlimited)")
+	flag.IntVar(&toolConfig.Retries, "retries", 2, "Retries for rate limit, server and timeout errors")
+	flag.BoolVar(&toolConfig.JSON, "json", false, "Output run summary as JSON (with --out)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
+
+	flags.ReorderAndParse()
+
+	return toolConfig
+}
@@ -0,0 +1,128 @@
+package main
+
+// DESCRIPTION: export a thread or session to markdown or HTML
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/io"
+)
+
+type ToolConfig struct {
+	Session string
+	Thread  string
+	Format  string
+	Title   string
+	Usage   bool
+	Clip    bool
+	File    string
+	JSON    bool
+}
+
+func main() {
+	toolConfig := parseFlags()
+
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "list" {
+		listConversations(toolConfig)
+		return
+	}
+	if len(args) > 0 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	var conv *ai.Conversation
+	var err error
+	if toolConfig.Session != "" {
+		conv, err = ai.LoadSessionConversation(toolConfig.Session)
+	} else {
+		conv, err = ai.LoadThreadConversation(toolConfig.Thread)
+	}
+	ai.ExitIf(err, "failed to load conversation")
+	if len(conv.Messages) == 0 {
+		ai.LogError("%s %s has no messages", conv.Source, conv.Name)
+		os.Exit(1)
+	}
+	if toolConfig.Title != "" {
+		conv.Title = toolConfig.Title
+	}
+
+	format := resolveFormat(toolConfig)
+	var content string
+	switch format {
+	case "md", "markdown":
+		content = conv.Markdown(toolConfig.Usage)
+	case "html":
+		content, err = io.ConversationHTML(conv, toolConfig.Usage)
+		ai.ExitIf(err, "failed to render HTML")
+	default:
+		ai.LogError("unknown format: %s (use md or html)", format)
+		os.Exit(1)
+	}
+
+	// Print raw content on stdout so it can be redirected; glamour would mangle HTML
+	if !toolConfig.Clip && toolConfig.File == "" {
+		fmt.Print(content)
+		return
+	}
+	io.DirectOutput(content, toolConfig.Clip, toolConfig.File, false)
+}
+
+// resolveFormat uses --format, else the --file extension, else markdown
+func resolveFormat(toolConfig ToolConfig) string {
+	if toolConfig.Format != "" {
+		return strings.ToLower(toolConfig.Format)
+	}
+	switch strings.ToLower(filepath.Ext(toolConfig.File)) {
+	case ".html", ".htm":
+		return "html"
+	}
+	return "md"
+}
+
+// listConversations prints exportable threads and sessions, newest first
+func listConversations(toolConfig ToolConfig) {
+	infos, err := ai.ListConversations()
+	ai.ExitIf(err, "failed to list conversations")
+
+	if toolConfig.JSON {
+		io.DirectOutput(infos, toolConfig.Clip, toolConfig.File, true)
+		return
+	}
+	if len(infos) == 0 {
+		ai.LogInfo("No threads or sessions found")
+		return
+	}
+
+	for _, info := range infos {
+		fmt.Printf("%-8s %-32s %4d messages  %s\n", info.Source, info.Name, info.Messages, info.Updated.Format("2006-01-02 15:04"))
+	}
+}
+
+func printUsage() {
+	ai.LogError("Usage: aiexport [list] [--session <name> | --thread <name>] [--format md|html] [--usage] [--title <title>] [--file <path>] [--clip]")
+}
+
+func parseFlags() ToolConfig {
+	toolConfig := ToolConfig{}
+
+	flag.StringVar(&toolConfig.Session, "session", "", "Export a named session")
+	flag.StringVar(&toolConfig.Thread, "thread", "", "Export a ChatGPT thread (default: most recent)")
+	flag.StringVar(&toolConfig.Format, "format", "", "Output format: md or html (default: from --file extension, else md)")
+	flag.StringVar(&toolConfig.Title, "title", "", "Document title (default: thread or session name)")
+	flag.BoolVar(&toolConfig.Usage, "usage", false, "Include per-turn and total token usage")
+	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy output to clipboard")
+	flag.StringVar(&toolConfig.File, "file", "", "Write output to file")
+	flag.BoolVar(&toolConfig.JSON, "json", false, "Output list as JSON")
+
+	flags.ReorderAndParse()
+
+	return toolConfig
+}
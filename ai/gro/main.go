@@ -9,28 +9,39 @@ import (
 	"cli-go/_internal/config"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 	"os"
 	"path/filepath"
 	"time"
 )
 
 type ToolConfig struct {
-	Clip   bool
-	File   string
-	JSON   bool
-	Prompt string
-	Test   bool
+	Clip       bool
+	File       string
+	JSON       bool
+	Prompt     string
+	Test       bool
+	Persona    string
+	Generation *ai.GenerationFlags
+	Session    string
+	NoRedact   bool
 }
 
 func main() {
 
 	toolConfig := parseFlags()
 
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Handle prompt selection
 	var promptFile string
 	if toolConfig.Prompt != "" {
-		// Use provided prompt path
-		promptFile = toolConfig.Prompt
+		// Resolve prompt by path, ID or name
+		selectedPrompt, err := ai.NewPromptClient().Resolve(toolConfig.Prompt)
+		ai.ExitIf(err, "failed to resolve prompt")
+		promptFile = selectedPrompt
 	} else if toolConfig.Test {
 		// Test mode: use specific test prompt
 		config, err := config.LoadConfig()
@@ -75,16 +86,27 @@ func main() {
 	// Create Grok client (no API key needed for CLI wrapper)
 	client := ai.NewGrokClient()
 
+	// Apply persona, generation parameters and session (--persona, --session, config)
+	err = ai.SetupToolClient(client, ai.ToolClientOptions{
+		Tool:       "gro",
+		Persona:    toolConfig.Persona,
+		NoRedact:   toolConfig.NoRedact,
+		Generation: toolConfig.Generation,
+		UseSession: true,
+		Session:    toolConfig.Session,
+	})
+	ai.ExitIf(err, "failed to set up client")
+
 	// Send message with prompt and track timing
 	start := time.Now()
-	response, err := client.SendMessageWithPrompt(promptFile, message)
+	response, err := client.SendMessageWithPrompt(ctx, promptFile, message)
 	duration := time.Since(start)
 	responseInfo := ai.ResponseInfo{
 		Duration: duration,
 		Model:    client.GetModel(),
 	}
 
-	ai.ExitIf(err, "failed to send message to Grok")
+	ai.ExitIfAPI(err, "failed to send message to Grok", toolConfig.JSON)
 
 	// Detect files in response
 	files := client.DetectFiles(response)
@@ -121,7 +143,11 @@ func parseFlags() ToolConfig {
 	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy to clipboard")
 	flag.StringVar(&toolConfig.File, "file", "", "Write to file")
 	flag.BoolVar(&toolConfig.JSON, "json", false, "Output in JSON format")
-	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Path to prompt file (skips interactive selection)")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Session, "session", "", "Named session shared across AI tools (default: $AI_SESSION)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
+	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Prompt file path, name or ID (skips interactive selection)")
 	flag.BoolVar(&toolConfig.Test, "test", false, "Test mode - use translate.md prompt")
 
 	flags.ReorderAndParse()
@@ -6,23 +6,32 @@ import (
 	"flag"
 	"cli-go/_internal/ai"
 	"cli-go/_internal/config"
+	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
-	"os"
+	"cli-go/_internal/sys"
 	"path/filepath"
 	"strings"
 )
 
 type ToolConfig struct {
-	Clip   bool
-	File   string
-	JSON   bool
-	Prompt string
-	Test   bool
+	Clip       bool
+	File       string
+	JSON       bool
+	Prompt     string
+	Test       bool
+	Persona    string
+	Generation *ai.GenerationFlags
+	Session    string
+	NoRedact   bool
 }
 
 func main() {
 	toolConfig := parseFlags()
 
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Check for help command
 	args := flag.Args()
 	if len(args) > 0 && args[0] == "help" {
@@ -38,8 +47,9 @@ func main() {
 	var err error
 
 	if toolConfig.Prompt != "" {
-		// Use provided prompt file for testing
-		promptFile = toolConfig.Prompt
+		// Resolve prompt by path, ID or name
+		promptFile, err = ai.NewPromptClient().Resolve(toolConfig.Prompt)
+		ai.ExitIf(err, "failed to resolve prompt")
 	} else if toolConfig.Test {
 		// Test mode: use specific test prompt
 		config, err := config.LoadConfig()
@@ -52,17 +62,8 @@ func main() {
 		ai.ExitIf(err, "failed to select prompt")
 	}
 
-	// Get additional message if provided (filter out flags)
-	var additionalMessage string
-	var filteredArgs []string
-	for _, arg := range os.Args[1:] {
-		if !strings.HasPrefix(arg, "--") && !strings.HasPrefix(arg, "-") {
-			filteredArgs = append(filteredArgs, arg)
-		}
-	}
-	if len(filteredArgs) > 0 {
-		additionalMessage = strings.Join(filteredArgs, " ")
-	}
+	// Get additional message from positional args (flags already parsed out)
+	additionalMessage := strings.Join(args, " ")
 
 	// If no additional message, use a default message
 	if additionalMessage == "" {
@@ -72,9 +73,20 @@ func main() {
 	// Create Grok client (no API key needed for CLI wrapper)
 	client := ai.NewGrokClient()
 
+	// Apply persona, generation parameters and session (--persona, --session, config)
+	err = ai.SetupToolClient(client, ai.ToolClientOptions{
+		Tool:       "grop",
+		Persona:    toolConfig.Persona,
+		NoRedact:   toolConfig.NoRedact,
+		Generation: toolConfig.Generation,
+		UseSession: true,
+		Session:    toolConfig.Session,
+	})
+	ai.ExitIf(err, "failed to set up client")
+
 	// Send message with prompt
-	response, err := client.SendMessageWithPrompt(promptFile, additionalMessage)
-	ai.ExitIf(err, "failed to send message to Grok")
+	response, err := client.SendMessageWithPrompt(ctx, promptFile, additionalMessage)
+	ai.ExitIfAPI(err, "failed to send message to Grok", toolConfig.JSON)
 
 	// Detect files in response
 	files := client.DetectFiles(response)
@@ -111,10 +123,14 @@ func parseFlags() ToolConfig {
 	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy to clipboard")
 	flag.StringVar(&toolConfig.File, "file", "", "Write to file")
 	flag.BoolVar(&toolConfig.JSON, "json", false, "Output in JSON format")
-	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Prompt file path (for testing, bypasses fzf)")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Session, "session", "", "Named session shared across AI tools (default: $AI_SESSION)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
+	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Prompt file path, name or ID (bypasses fzf)")
 	flag.BoolVar(&toolConfig.Test, "test", false, "Test mode - use translate.md prompt and default message")
 
-	flag.Parse()
+	flags.ReorderAndParse()
 
 	return toolConfig
 }
@@ -5,22 +5,30 @@ package main
 import (
 	"flag"
 	"cli-go/_internal/ai"
-	"cli-go/_internal/config"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 	"os"
 )
 
 type ToolConfig struct {
-	Clip bool
-	File string
-	JSON bool
+	Clip       bool
+	File       string
+	JSON       bool
+	Persona    string
+	Generation *ai.GenerationFlags
+	Session    string
+	NoRedact   bool
 }
 
 func main() {
 
 	toolConfig := parseFlags()
 
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Detect input mode
 	inputMode := ai.DetectInputMode()
 
@@ -40,19 +48,21 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create Claude client with Haiku model
-	// Get credential store (READ-ONLY)
-
-	// Get Anthropic key from store (READ-ONLY)
-	apiKey, err := config.GetKey("anthropic")
-	ai.ExitIf(err, "failed to get Anthropic API key")
-
-	// Create Claude client with API key
-	client := ai.NewClaudeClient(ai.ClaudeHaiku, apiKey)
+	// Create the client with persona, generation parameters and session (--persona, --session, config)
+	client, err := ai.NewToolClient(ai.ToolClientOptions{
+		Tool:       "haik",
+		Provider:   "haiku",
+		Persona:    toolConfig.Persona,
+		NoRedact:   toolConfig.NoRedact,
+		Generation: toolConfig.Generation,
+		UseSession: true,
+		Session:    toolConfig.Session,
+	})
+	ai.ExitIf(err, "failed to create Claude client")
 
 	// Send message with timing
-	response, responseInfo, err := ai.SendMessageWithTiming(client, message)
-	ai.ExitIf(err, "failed to send message to Claude")
+	response, responseInfo, err := ai.SendMessageWithTiming(ctx, client, message)
+	ai.ExitIfAPI(err, "failed to send message to Claude", toolConfig.JSON)
 
 	// Format output based on --json flag
 	if toolConfig.JSON {
@@ -77,6 +87,10 @@ func parseFlags() ToolConfig {
 	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy to clipboard")
 	flag.StringVar(&toolConfig.File, "file", "", "Write to file")
 	flag.BoolVar(&toolConfig.JSON, "json", false, "Output in JSON format")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Session, "session", "", "Named session shared across AI tools (default: $AI_SESSION)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
 
 	flags.ReorderAndParse()
 
@@ -8,19 +8,28 @@ import (
 	"cli-go/_internal/config"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 	"os"
 )
 
 type ToolConfig struct {
-	Clip bool
-	File string
-	JSON bool
+	Clip       bool
+	File       string
+	JSON       bool
+	Persona    string
+	Generation *ai.GenerationFlags
+	Session    string
+	NoRedact   bool
 }
 
 func main() {
 
 	toolConfig := parseFlags()
 
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Remove existing input.md
 	os.Remove("input.md")
 
@@ -58,8 +67,19 @@ func main() {
 
 	// Create ChatGPT client with API key
 	client := ai.NewChatGPTClient("text", apiKey)
-	response, responseInfo, err := ai.SendMessageWithTiming(client, string(content))
-	ai.ExitIf(err, "failed to send message to ChatGPT")
+
+	// Apply persona, generation parameters and session (--persona, --session, config)
+	err = ai.SetupToolClient(client, ai.ToolClientOptions{
+		Tool:       "ji",
+		Persona:    toolConfig.Persona,
+		NoRedact:   toolConfig.NoRedact,
+		Generation: toolConfig.Generation,
+		UseSession: true,
+		Session:    toolConfig.Session,
+	})
+	ai.ExitIf(err, "failed to set up client")
+	response, responseInfo, err := ai.SendMessageWithTiming(ctx, client, string(content))
+	ai.ExitIfAPI(err, "failed to send message to ChatGPT", toolConfig.JSON)
 
 	// Format output based on --json flag
 	if toolConfig.JSON {
@@ -90,6 +110,10 @@ func parseFlags() ToolConfig {
 	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy to clipboard")
 	flag.StringVar(&toolConfig.File, "file", "", "Write to file")
 	flag.BoolVar(&toolConfig.JSON, "json", false, "Output in JSON format")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Session, "session", "", "Named session shared across AI tools (default: $AI_SESSION)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
 
 	flags.ReorderAndParse()
 
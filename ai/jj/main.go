@@ -8,21 +8,30 @@ import (
 	"cli-go/_internal/config"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 	"os"
 	"time"
 )
 
 type ToolConfig struct {
-	Clip   bool
-	File   string
-	JSON   bool
-	Prompt string
+	Clip       bool
+	File       string
+	JSON       bool
+	Prompt     string
+	Persona    string
+	Generation *ai.GenerationFlags
+	Session    string
+	NoRedact   bool
 }
 
 func main() {
 
 	toolConfig := parseFlags()
 
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Detect input mode
 	inputMode := ai.DetectInputMode()
 
@@ -49,14 +58,29 @@ func main() {
 	// Create ChatGPT client with API key
 	client := ai.NewChatGPTClient("json", apiKey)
 
+	// Apply persona, generation parameters and session (--persona, --session, config)
+	err = ai.SetupToolClient(client, ai.ToolClientOptions{
+		Tool:       "jj",
+		Persona:    toolConfig.Persona,
+		NoRedact:   toolConfig.NoRedact,
+		Generation: toolConfig.Generation,
+		UseSession: true,
+		Session:    toolConfig.Session,
+	})
+	ai.ExitIf(err, "failed to set up client")
+
 	// Send message with or without prompt file and track response time
 	var response string
 	var responseInfo ai.ResponseInfo
 
 	if toolConfig.Prompt != "" {
+		// Resolve prompt by path, ID or name
+		promptFile, resolveErr := ai.NewPromptClient().Resolve(toolConfig.Prompt)
+		ai.ExitIf(resolveErr, "failed to resolve prompt")
+
 		// Use prompt file - need to track timing manually
 		start := time.Now()
-		response, err = client.SendMessageWithRoleFile(toolConfig.Prompt, message)
+		response, err = client.SendMessageWithRoleFile(ctx, promptFile, message)
 		duration := time.Since(start)
 		responseInfo = ai.ResponseInfo{
 			Duration: duration,
@@ -64,10 +88,10 @@ func main() {
 		}
 	} else {
 		// Send message directly with timing
-		response, responseInfo, err = ai.SendMessageWithTiming(client, message)
+		response, responseInfo, err = ai.SendMessageWithTiming(ctx, client, message)
 	}
 
-	ai.ExitIf(err, "failed to send message to ChatGPT")
+	ai.ExitIfAPI(err, "failed to send message to ChatGPT", toolConfig.JSON)
 
 	// Format output based on --json flag
 	if toolConfig.JSON {
@@ -93,7 +117,11 @@ func parseFlags() ToolConfig {
 	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy to clipboard")
 	flag.StringVar(&toolConfig.File, "file", "", "Write to file")
 	flag.BoolVar(&toolConfig.JSON, "json", false, "Output in JSON format")
-	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Path to prompt file")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Session, "session", "", "Named session shared across AI tools (default: $AI_SESSION)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
+	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Prompt file path, name or ID (see 'prompts list')")
 
 	flags.ReorderAndParse()
 
@@ -8,24 +8,32 @@ import (
 	"cli-go/_internal/config"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 )
 
 type ToolConfig struct {
-	Clip   bool
-	File   string
-	JSON   bool
-	Prompt string
-	Test   bool
+	Clip       bool
+	File       string
+	JSON       bool
+	Prompt     string
+	Test       bool
+	Persona    string
+	Generation *ai.GenerationFlags
+	Session    string
+	NoRedact   bool
 }
 
 func main() {
 
 	toolConfig := parseFlags()
 
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Create prompt client
 	promptClient := ai.NewPromptClient()
 
@@ -34,8 +42,9 @@ func main() {
 	var err error
 
 	if toolConfig.Prompt != "" {
-		// Use provided prompt file
-		promptFile = toolConfig.Prompt
+		// Resolve prompt by path, ID or name
+		promptFile, err = promptClient.Resolve(toolConfig.Prompt)
+		ai.ExitIf(err, "failed to resolve prompt")
 	} else if toolConfig.Test {
 		// Test mode: use specific test prompt
 		config, err := config.LoadConfig()
@@ -51,18 +60,8 @@ func main() {
 	promptContent, err := promptClient.LoadPrompt(promptFile)
 	ai.ExitIf(err, "failed to load prompt")
 
-	// Get additional message if provided
-	var additionalMessage string
-	if len(os.Args) > 1 {
-		// Filter out --json flag
-		var filteredArgs []string
-		for _, arg := range os.Args[1:] {
-			if arg != "--json" {
-				filteredArgs = append(filteredArgs, arg)
-			}
-		}
-		additionalMessage = strings.Join(filteredArgs, " ")
-	}
+	// Get additional message from positional args (flags already parsed out)
+	additionalMessage := ai.GetArgs()
 
 	// If no additional message, get it interactively
 	if additionalMessage == "" {
@@ -89,34 +88,32 @@ func main() {
 	}
 	client := ai.NewChatGPTClient(format, apiKey)
 
+	// Apply persona, generation parameters and session (--persona, --session, config)
+	err = ai.SetupToolClient(client, ai.ToolClientOptions{
+		Tool:       "jp",
+		Persona:    toolConfig.Persona,
+		NoRedact:   toolConfig.NoRedact,
+		Generation: toolConfig.Generation,
+		UseSession: true,
+		Session:    toolConfig.Session,
+	})
+	ai.ExitIf(err, "failed to set up client")
+
 	// Send message with role file and track timing
 	start := time.Now()
-	response, err := client.SendMessageWithRoleFile(promptFile, additionalMessage)
+	response, err := client.SendMessageWithRoleFile(ctx, promptFile, additionalMessage)
 	duration := time.Since(start)
 	responseInfo := ai.ResponseInfo{
 		Duration: duration,
 		Model:    client.GetModel(),
 	}
 
-	ai.ExitIf(err, "failed to send message to ChatGPT")
+	ai.ExitIfAPI(err, "failed to send message to ChatGPT", toolConfig.JSON)
 
 	// Format output based on --json flag
 	if toolConfig.JSON {
 		// JSON output when --json flag is provided
-		// Extract prompt name from file path
-		var promptName string
-		// Extract prompt name by removing base directory and .md extension
-		config, err := config.LoadConfig()
-		if err == nil {
-			baseDir := config.Prompts.BaseDir
-			if strings.HasPrefix(promptFile, baseDir) {
-				promptName = strings.TrimSuffix(strings.TrimPrefix(promptFile, baseDir+"/"), ".md")
-			}
-		}
-		if promptName == "" {
-			// Fallback: extract from filename
-			promptName = strings.TrimSuffix(filepath.Base(promptFile), ".md")
-		}
+		promptName := promptClient.PromptName(promptFile)
 
 		jsonData := map[string]interface{}{
 			"response":    response,
@@ -141,7 +138,11 @@ func parseFlags() ToolConfig {
 	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy to clipboard")
 	flag.StringVar(&toolConfig.File, "file", "", "Write to file")
 	flag.BoolVar(&toolConfig.JSON, "json", false, "Output in JSON format")
-	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Path to prompt file")
+	flag.StringVar(&toolConfig.Persona, "persona", "", "Persona from config (system prompt, model, temperature)")
+	flag.StringVar(&toolConfig.Session, "session", "", "Named session shared across AI tools (default: $AI_SESSION)")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
+	flag.StringVar(&toolConfig.Prompt, "prompt", "", "Prompt file path, name or ID (see 'prompts list')")
 	flag.BoolVar(&toolConfig.Test, "test", false, "Test mode - use translate.md prompt")
 
 	flags.ReorderAndParse()
@@ -0,0 +1,182 @@
+package main
+
+// DESCRIPTION: run multi-step prompt pipelines (YAML)
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/io"
+	"cli-go/_internal/pipeline"
+	"cli-go/_internal/sys"
+)
+
+type ToolConfig struct {
+	Clip       bool
+	File       string
+	JSON       bool
+	Quiet      bool
+	NoRedact   bool
+	Generation *ai.GenerationFlags
+}
+
+// RunReport is the --json output of a pipeline run
+type RunReport struct {
+	Pipeline   string                `json:"pipeline"`
+	Success    bool                  `json:"success"`
+	Error      string                `json:"error,omitempty"`
+	Steps      []pipeline.StepResult `json:"steps"`
+	Output     string                `json:"output"`
+	DurationMs int64                 `json:"duration_ms"`
+}
+
+func main() {
+	toolConfig := parseFlags()
+
+	// Cancel the running step cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "list":
+		listPipelines(toolConfig)
+	case "show":
+		if len(args) < 2 {
+			ai.LogError("Usage: pipeline show <name>")
+			os.Exit(1)
+		}
+		p, err := pipeline.Load(args[1])
+		ai.ExitIf(err, "failed to load pipeline")
+		io.DirectOutput(p, toolConfig.Clip, toolConfig.File, true)
+	case "run":
+		if len(args) < 2 {
+			ai.LogError("Usage: pipeline run <name> [input...]")
+			os.Exit(1)
+		}
+		runPipeline(ctx, toolConfig, args[1], strings.Join(args[2:], " "))
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+// runPipeline executes a pipeline, printing each step's output and timing as it completes
+func runPipeline(ctx context.Context, toolConfig ToolConfig, name, input string) {
+	p, err := pipeline.Load(name)
+	ai.ExitIf(err, "failed to load pipeline")
+
+	// Input comes from the remaining arguments or piped stdin
+	if input == "" && ai.DetectInputMode() == ai.InputStdin {
+		input, err = ai.ReadStdin()
+		ai.ExitIf(err, "failed to read stdin")
+	}
+
+	ai.LogInfo("🔗 Running pipeline %s (%d steps)", p.Name, len(p.Steps))
+
+	start := time.Now()
+	step := 0
+	results, runErr := pipeline.Run(ctx, p, input, pipeline.RunOptions{
+		Generation: toolConfig.Generation,
+		NoRedact:   toolConfig.NoRedact,
+	}, func(result pipeline.StepResult) {
+		step++
+		status := "✅"
+		if result.Error != "" {
+			status = "❌"
+		}
+		detail := result.Kind
+		if result.Model != "" {
+			detail += ", " + result.Model
+		}
+		duration := (time.Duration(result.DurationMs) * time.Millisecond).String()
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s %s (%s) %s\n", step, len(p.Steps), status, result.ID, detail, duration)
+
+		// Intermediate outputs go to stderr so stdout carries only the final result
+		if !toolConfig.Quiet && !toolConfig.JSON && result.Error == "" && step < len(p.Steps) {
+			fmt.Fprintf(os.Stderr, "\n%s\n\n", result.Output)
+		}
+	})
+
+	report := RunReport{
+		Pipeline:   p.Name,
+		Success:    runErr == nil,
+		Steps:      results,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if len(results) > 0 {
+		report.Output = results[len(results)-1].Output
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+
+	if toolConfig.JSON {
+		io.DirectOutput(report, toolConfig.Clip, toolConfig.File, true)
+	} else if runErr == nil {
+		io.DirectOutput(report.Output, toolConfig.Clip, toolConfig.File, false)
+	}
+
+	if runErr != nil {
+		ai.LogError("%v", runErr)
+		os.Exit(ai.ExitCode(runErr))
+	}
+	ai.LogInfo("🏁 Pipeline %s finished in %s", p.Name, time.Since(start).Round(time.Millisecond))
+}
+
+// listPipelines prints the available pipelines with their steps
+func listPipelines(toolConfig ToolConfig) {
+	pipelines, err := pipeline.List()
+	ai.ExitIf(err, "failed to list pipelines")
+
+	if toolConfig.JSON {
+		io.DirectOutput(pipelines, toolConfig.Clip, toolConfig.File, true)
+		return
+	}
+
+	if len(pipelines) == 0 {
+		ai.LogInfo("No pipelines in %s", pipeline.Dir())
+		return
+	}
+
+	var b strings.Builder
+	for _, p := range pipelines {
+		var steps []string
+		for _, s := range p.Steps {
+			steps = append(steps, s.ID)
+		}
+		fmt.Fprintf(&b, "%-24s %s\n", p.Name, p.Description)
+		fmt.Fprintf(&b, "%-24s steps: %s\n", "", strings.Join(steps, " → "))
+	}
+	io.DirectOutput(b.String(), toolConfig.Clip, toolConfig.File, false)
+}
+
+func printUsage() {
+	ai.LogError("Usage: pipeline <list|show <name>|run <name> [input...]> [--json] [--quiet]")
+}
+
+func parseFlags() ToolConfig {
+	toolConfig := ToolConfig{}
+
+	flag.BoolVar(&toolConfig.Clip, "clip", false, "Copy final output to clipboard")
+	flag.StringVar(&toolConfig.File, "file", "", "Write final output to file")
+	flag.BoolVar(&toolConfig.JSON, "json", false, "Output all step results as JSON")
+	flag.BoolVar(&toolConfig.Quiet, "quiet", false, "Only show step timing, not intermediate outputs")
+	flag.BoolVar(&toolConfig.NoRedact, "no-redact", false, "Send content without masking secrets")
+	toolConfig.Generation = ai.RegisterGenerationFlags()
+
+	flags.ReorderAndParse()
+
+	return toolConfig
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"cli-go/_internal/ai"
 	"cli-go/_internal/config"
+	"cli-go/_internal/flags"
 	"cli-go/_internal/io"
 	"os"
 	"os/exec"
+	"strings"
 )
 
 type PromptsResult struct {
@@ -27,8 +29,35 @@ func main() {
 		file   = flag.String("file", "", "Write to file")
 		editor = flag.String("editor", "cursor", "Editor to use (cursor, code, vim, etc.)")
 		json   = flag.Bool("json", false, "Output in JSON format")
+		tag    = flag.String("tag", "", "Comma-separated tags to filter by (list, search)")
 	)
-	flag.Parse()
+	flags.ReorderAndParse()
+
+	// Handle index subcommands
+	args := flag.Args()
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			listPrompts("", splitTags(*tag), *clip, *file, *json)
+			return
+		case "search":
+			if len(args) < 2 {
+				ai.LogError("Usage: prompts search <query> [--tag a,b] [--json]")
+				os.Exit(1)
+			}
+			listPrompts(strings.Join(args[1:], " "), splitTags(*tag), *clip, *file, *json)
+			return
+		case "path":
+			if len(args) < 2 {
+				ai.LogError("Usage: prompts path <name-or-id>")
+				os.Exit(1)
+			}
+			path, err := ai.NewPromptClient().Resolve(args[1])
+			ai.ExitIf(err, "failed to resolve prompt")
+			fmt.Println(path)
+			return
+		}
+	}
 
 	// Load config to get prompts directory
 	config, err := config.LoadConfig()
@@ -86,3 +115,54 @@ func main() {
 		io.FormatTerminalOutput(result.Message)
 	}
 }
+
+// listPrompts prints the prompt index, optionally filtered by a full-text query and tags
+func listPrompts(query string, tags []string, clip bool, file string, asJSON bool) {
+	promptClient := ai.NewPromptClient()
+
+	var entries []ai.PromptEntry
+	var err error
+	if query == "" && len(tags) == 0 {
+		entries, err = promptClient.Index()
+	} else {
+		entries, err = promptClient.Search(query, tags)
+	}
+	ai.ExitIf(err, "failed to index prompts")
+
+	if asJSON {
+		if entries == nil {
+			entries = []ai.PromptEntry{}
+		}
+		io.DirectOutput(entries, clip, file, asJSON)
+		return
+	}
+
+	if len(entries) == 0 {
+		io.LogInfo("No prompts found")
+		return
+	}
+
+	var b strings.Builder
+	for _, entry := range entries {
+		line := fmt.Sprintf("- `%s` **%s**", entry.ID, entry.Name)
+		if len(entry.Tags) > 0 {
+			line += "  [" + strings.Join(entry.Tags, ", ") + "]"
+		}
+		if entry.Title != "" {
+			line += " — " + entry.Title
+		}
+		b.WriteString(line + "\n")
+	}
+	io.DirectOutput(b.String(), clip, file, false)
+}
+
+// splitTags splits a comma-separated tag list
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
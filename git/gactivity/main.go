@@ -3,6 +3,7 @@ package main
 // DESCRIPTION: show user activity across repositories
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"cli-go/_internal/ai"
@@ -10,6 +11,9 @@ import (
 	"cli-go/_internal/git"
 	"cli-go/_internal/github"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"path/filepath"
+	"strings"
 )
 
 type Config struct {
@@ -24,6 +28,10 @@ func main() {
 
 	cfg := parseFlags()
 
+	// Cancel in-flight gh calls on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Get repositories to process
 	// Default to main repos if no flags provided
 	defaultMode := "main"
@@ -37,26 +45,39 @@ func main() {
 	configData, err := config.LoadConfig()
 	ai.ExitIf(err, "failed to load config")
 
-	// Get branches using git library
-	var allBranches []git.Branch
-	for _, repoPath := range repoPaths {
-		branches, err := git.GetUserBranches(repoPath, configData.Ringier.DefaultUser)
+	// Get branches using git library, one worker per repository
+	branchResults := git.RunParallel(ctx, repoPaths, git.PoolOptions{Label: "Reading branches"}, func(ctx context.Context, repoPath string) ([]git.Branch, error) {
+		branches, err := git.GetUserBranches(ctx, repoPath, configData.Ringier.DefaultUser)
+		if err != nil {
+			return nil, err
+		}
+		// Match branches to PRs by head branch, one gh call per repository
+		prs, err := git.ListBranchPRs(ctx, repoPath)
 		if err != nil {
-			io.LogWarning("Failed to get branches from %s: %v", repoPath, err)
-			continue
+			io.LogWarning("%s: no PR state: %v", filepath.Base(repoPath), err)
 		}
-		allBranches = append(allBranches, branches...)
+		git.AttachPRs(branches, prs)
+		return branches, nil
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(branchResults)
+
+	var allBranches []git.Branch
+	for _, result := range branchResults {
+		allBranches = append(allBranches, result.Value...)
 	}
 
 	// Get PRs using github library
+	prResults := git.RunParallel(ctx, github.RepoSlugs(configData.Repositories), git.PoolOptions{Label: "Fetching PRs"}, func(ctx context.Context, slug string) ([]github.PR, error) {
+		owner, repo := github.SplitSlug(slug)
+		return github.GetUserOpenPRs(ctx, configData.Ringier.DefaultUser, owner, repo)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(prResults)
+
 	var allPRs []github.PR
-	for _, repoConfig := range configData.Repositories {
-		prs, err := github.GetUserOpenPRs(configData.Ringier.DefaultUser, repoConfig.Owner, repoConfig.Repo)
-		if err != nil {
-			io.LogWarning("Failed to get PRs from %s/%s: %v", repoConfig.Owner, repoConfig.Repo, err)
-			continue
-		}
-		allPRs = append(allPRs, prs...)
+	for _, result := range prResults {
+		allPRs = append(allPRs, result.Value...)
 	}
 
 	// Format output
@@ -98,8 +119,18 @@ func outputDefault(activity map[string]interface{}) {
 		if branchList, ok := branches.([]git.Branch); ok {
 			if len(branchList) > 0 {
 				fmt.Printf("🌿 Your branches:\n")
+				withoutPR := 0
 				for _, branch := range branchList {
-					fmt.Printf("  • %s (%s)\n", branch.Name, branch.RepoPath)
+					summary := prSummary(branch)
+					if !branch.HasPR && git.IsLongLivedBranch(branch.RepoPath, branch.Name) {
+						summary = ""
+					} else if !branch.HasPR {
+						withoutPR++
+					}
+					fmt.Println(strings.TrimRight(fmt.Sprintf("  • %s (%s) %s", branch.Name, branch.RepoPath, summary), " "))
+				}
+				if withoutPR > 0 {
+					fmt.Printf("⚠️  Branches without a PR yet: %d\n", withoutPR)
 				}
 			} else {
 				fmt.Printf("🌿 No branches found\n")
@@ -120,3 +151,18 @@ func outputDefault(activity map[string]interface{}) {
 		}
 	}
 }
+
+// prSummary describes the PR of a branch, e.g. "#42 open, approved", or flags a missing PR
+func prSummary(branch git.Branch) string {
+	if !branch.HasPR {
+		return "⚠️  no PR"
+	}
+	state := strings.ToLower(branch.PRState)
+	if branch.PRDraft && branch.PRState == "OPEN" {
+		state = "draft"
+	}
+	if branch.ReviewDecision != "" && branch.PRState == "OPEN" {
+		state += ", " + strings.ToLower(strings.ReplaceAll(branch.ReviewDecision, "_", " "))
+	}
+	return fmt.Sprintf("→ #%d %s", branch.PRNumber, state)
+}
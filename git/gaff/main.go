@@ -13,13 +13,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 )
 
 type Config struct {
 	Compact bool
 	JSON    bool
 	Test    bool
+	Base    string
+	Stat    bool
+	Diff    bool
+	Side    bool
+	Patch   string
+	Only    string
+	Status  string
+	Width   int
 }
 
 func main() {
@@ -30,19 +37,31 @@ func main() {
 	if len(args) > 0 && args[0] == "help" {
 		io.LogInfo("gaff - Show changed files vs fork-point")
 		io.LogInfo("Shows files that have changed since the fork-point branch")
-		io.LogInfo("Opens selected file in diff mode with editor")
-		io.LogInfo("Output: {\"files\": [\"file1.go\", \"file2.ts\"], \"base_branch\": \"origin/main\", \"selected\": \"file1.go\"}")
+		io.LogInfo("Opens the given (or fzf-selected) file in diff mode with editor")
+		io.LogInfo("Diff modes: --stat (per-file +/- counts), --diff (unified), --side (side-by-side), --patch <file|-> (patch for git apply)")
+		io.LogInfo("Filters: --only 'src/,*.go' (or paths as arguments in diff modes), --status a,m,d,r (added, modified, deleted, renamed)")
+		io.LogInfo("Flags: --base <branch> (override and remember the detected base branch), --width N (side-by-side width)")
+		io.LogInfo("Output: {\"files\": [\"file1.go\", \"file2.ts\"], \"changes\": [...], \"base_branch\": \"origin/main\", \"selected\": \"file1.go\"}")
+		return
+	}
+
+	git.RemoveSnapshots()
+
+	filter := git.ChangeFilter{Paths: splitList(config.Only), Statuses: splitList(config.Status)}
+	if config.Stat || config.Diff || config.Side || config.Patch != "" {
+		// In diff modes arguments are path filters
+		filter.Paths = append(filter.Paths, args...)
+		runDiffMode(config, filter)
 		return
 	}
 
 	// Check if a specific file was provided as argument (skip flags)
 	var selectedFile string
-	fileArgs := flag.Args()
-	if len(fileArgs) > 0 && !strings.HasPrefix(fileArgs[0], "-") {
-		selectedFile = fileArgs[0]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		selectedFile = args[0]
 	}
 
-	forkInfo, err := git.GetChangedFilesWithSelection(selectedFile, config.Test)
+	forkInfo, err := git.GetChangedFilesWithSelection(selectedFile, config.Base, filter, config.Test)
 	ai.ExitIf(err, "failed to get changed files")
 
 	// If no files changed, show message and exit
@@ -51,20 +70,100 @@ func main() {
 		return
 	}
 
-	// If a specific file was selected, open it in diff mode
-	if selectedFile != "" {
-		if err := openFileInDiffMode(selectedFile, forkInfo.BaseBranch); err != nil {
+	if config.JSON {
+		io.DirectOutput(forkInfo, *clip, *file, true)
+		return
+	}
+
+	// Open the given or selected file in diff mode
+	if forkInfo.Selected != "" && !config.Test {
+		basePath := forkInfo.Selected
+		for _, change := range forkInfo.Changes {
+			if change.Path == forkInfo.Selected && change.OldPath != "" {
+				basePath = change.OldPath
+			}
+		}
+		if err := openFileInDiffMode(forkInfo.Selected, basePath, forkInfo.BaseCommit); err != nil {
 			ai.ExitIf(err, "failed to open file in diff mode")
 		}
 		return
 	}
 
-	// If no specific file, show output for interactive selection
+	outputDefault(forkInfo)
+}
+
+// runDiffMode shows the changes since the fork point as stats, a diff or a patch
+func runDiffMode(config Config, filter git.ChangeFilter) {
+	base, err := git.ResolveBaseBranch(config.Base)
+	ai.ExitIf(err, "failed to determine base branch")
+
+	changes, err := git.GetChangesSince(base.ForkPoint)
+	ai.ExitIf(err, "failed to get changed files")
+	changes = git.FilterChanges(changes, filter)
+	if len(changes) == 0 {
+		io.LogInfo("no changes since fork-point")
+		return
+	}
+
+	if config.Patch != "" {
+		patch, err := git.GetDiffSince(base.ForkPoint, git.ChangePaths(changes), true)
+		ai.ExitIf(err, "failed to create patch")
+		if config.Patch == "-" {
+			fmt.Print(patch)
+			return
+		}
+		ai.ExitIf(os.WriteFile(config.Patch, []byte(patch), 0644), "failed to write patch")
+		io.LogSuccess("Patch with %d files written to %s", len(changes), config.Patch)
+		return
+	}
+
 	if config.JSON {
-		io.DirectOutput(forkInfo, *clip, *file, true)
+		info := git.ForkPointDiffInfo{BaseBranch: base.Branch, BaseCommit: base.ForkPoint, BaseReason: base.Reason, Changes: changes}
+		for _, change := range changes {
+			info.Files = append(info.Files, change.Path)
+		}
+		io.DirectOutput(info, *clip, *file, true)
+		return
+	}
+
+	if config.Stat {
+		io.DirectOutput(git.FormatChangeStat(changes, base), *clip, *file, false)
+		return
+	}
+
+	patch, err := git.GetDiffSince(base.ForkPoint, git.ChangePaths(changes), false)
+	ai.ExitIf(err, "failed to get diff")
+
+	// Colors only go to a terminal; --clip and --file get plain text
+	toTerminal := !*clip && *file == ""
+	color := toTerminal && io.UseColor()
+	var output string
+	if config.Side {
+		width := config.Width
+		if width <= 0 {
+			width = io.TerminalWidth(120)
+		}
+		output = io.RenderSideBySide(patch, width, color)
 	} else {
-		outputDefault(forkInfo)
+		output = io.RenderUnifiedDiff(patch, color)
+	}
+
+	if toTerminal {
+		fmt.Print(output)
+		return
 	}
+	io.DirectOutput(output, *clip, *file, false)
+}
+
+// splitList splits a comma-separated flag value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 var (
@@ -78,13 +177,22 @@ func parseFlags() Config {
 	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
 	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
 	flag.BoolVar(&config.Test, "test", false, "Test mode - pre-select first changed file")
+	flag.StringVar(&config.Base, "base", "", "Base branch to compare against (remembered for the current branch)")
+	flag.BoolVar(&config.Stat, "stat", false, "Show per-file added/deleted line counts")
+	flag.BoolVar(&config.Diff, "diff", false, "Show a colored unified diff")
+	flag.BoolVar(&config.Side, "side", false, "Show a side-by-side diff")
+	flag.StringVar(&config.Patch, "patch", "", "Write a patch (applicable with git apply) to file, - for stdout")
+	flag.StringVar(&config.Only, "only", "", "Comma-separated path patterns to include (e.g. 'src/,*.go')")
+	flag.StringVar(&config.Status, "status", "", "Comma-separated statuses to include: added, modified, deleted, renamed (or a,m,d,r)")
+	flag.IntVar(&config.Width, "width", 0, "Side-by-side width (default: terminal width)")
 
 	flags.ReorderAndParse()
 
 	return config
 }
 
-func openFileInDiffMode(filePath, baseBranch string) error {
+// openFileInDiffMode opens the fork-point version of basePath next to the current filePath in the editor
+func openFileInDiffMode(filePath, basePath, baseCommit string) error {
 	// Get repository root
 	repoRoot, err := git.GetGitRoot()
 	if err != nil {
@@ -104,38 +212,13 @@ func openFileInDiffMode(filePath, baseBranch string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get relative path: %v", err)
 	}
-
-	// Get current branch
-	currentBranch, err := git.GetCurrentBranch()
-	if err != nil {
-		return fmt.Errorf("failed to get current branch: %v", err)
-	}
-
-	// Create temporary directory for fork-point version
-	tmpDir := fmt.Sprintf("%s/gaff-%s-%s-%d",
-		os.TempDir(),
-		filepath.Base(repoRoot),
-		currentBranch,
-		os.Getpid())
-
-	tmpFile := filepath.Join(tmpDir, relPath)
-
-	// Create directory structure
-	if err := os.MkdirAll(filepath.Dir(tmpFile), 0755); err != nil {
-		return fmt.Errorf("failed to create temp dir: %v", err)
+	if basePath == filePath {
+		basePath = relPath
 	}
 
-	// Get fork-point version of the file
-	cmd := exec.Command("git", "show", fmt.Sprintf("%s:%s", baseBranch, relPath))
-	output, err := cmd.Output()
+	tmpDir, tmpFile, err := git.WriteSnapshot(repoRoot, baseCommit, basePath, relPath)
 	if err != nil {
-		// If file doesn't exist in fork-point, create empty file
-		output = []byte{}
-	}
-
-	// Write fork-point version to temp file
-	if err := os.WriteFile(tmpFile, output, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %v", err)
+		return err
 	}
 
 	// Get editor from environment
@@ -148,7 +231,7 @@ func openFileInDiffMode(filePath, baseBranch string) error {
 	editorParts := strings.Fields(editor)
 	editorCmd := editorParts[0]
 	editorArgs := editorParts[1:]
-	
+
 	// Remove -w flag as it's incompatible with --diff
 	var filteredArgs []string
 	for _, arg := range editorArgs {
@@ -160,47 +243,25 @@ func openFileInDiffMode(filePath, baseBranch string) error {
 	// Open editor in diff mode
 	// Cursor uses -d flag (without -r as it may conflict)
 	args := append(filteredArgs, "-d", tmpFile, absPath)
-	
-	// Debug output
-	fmt.Fprintf(os.Stderr, "Opening diff: %s %v\n", editorCmd, args)
-	
-	cmd = exec.Command(editorCmd, args...)
+
+	cmd := exec.Command(editorCmd, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	// Run in background
 	if err := cmd.Start(); err != nil {
+		os.RemoveAll(tmpDir)
 		return fmt.Errorf("failed to open editor: %v", err)
 	}
-	
-	fmt.Fprintf(os.Stderr, "Diff opened successfully\n")
-
-	// Clean up temp directory after a delay
-	go func() {
-		// Wait longer for the editor to fully load the files
-		time.Sleep(30 * time.Second)
-		os.RemoveAll(tmpDir)
-	}()
 
+	// The editor loads the snapshot after gaff exits; the next run removes it (git.RemoveSnapshots)
 	return nil
 }
 
-func outputDefault(forkInfo interface{}) {
-	if resultMap, ok := forkInfo.(map[string]interface{}); ok {
-		if files, exists := resultMap["files"]; exists {
-			if fileList, ok := files.([]string); ok {
-				if len(fileList) > 0 {
-					fmt.Printf("%s\n", io.FormatWithEmoji("Changed files since fork-point:", "file"))
-					for _, file := range fileList {
-						fmt.Printf("  • %s\n", file)
-					}
-				} else {
-					fmt.Printf("%s\n", io.FormatWithEmoji("No changes since fork-point", "file"))
-				}
-			}
-		}
-		if baseBranch, exists := resultMap["base_branch"]; exists {
-			fmt.Printf("%s\n", io.FormatWithEmoji(fmt.Sprintf("Base branch: %s", baseBranch), "branches"))
-		}
+func outputDefault(forkInfo *git.ForkPointDiffInfo) {
+	fmt.Printf("%s\n", io.FormatWithEmoji("Changed files since fork-point:", "file"))
+	for _, file := range forkInfo.Files {
+		fmt.Printf("  • %s\n", file)
 	}
+	fmt.Printf("%s\n", io.FormatWithEmoji(fmt.Sprintf("Base branch: %s (%s)", forkInfo.BaseBranch, forkInfo.BaseReason), "branches"))
 }
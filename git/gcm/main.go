@@ -13,6 +13,7 @@ import (
 type Config struct {
 	Compact bool
 	JSON    bool
+	Base    string
 }
 
 type CheckoutResult struct {
@@ -26,8 +27,9 @@ func main() {
 	config := parseFlags()
 
 	// Get fork-point branch
-	baseBranch, err := git.GetForkPointBranch()
+	base, err := git.ResolveBaseBranch(config.Base)
 	ai.ExitIf(err, "failed to get fork-point branch")
+	baseBranch := base.Branch
 
 	// Check if there are uncommitted changes
 	modified, err := git.GetModifiedFiles()
@@ -68,6 +70,7 @@ func parseFlags() Config {
 
 	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
 	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
+	flag.StringVar(&config.Base, "base", "", "Base branch to check out (remembered for the current branch)")
 
 	flag.Parse()
 
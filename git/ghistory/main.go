@@ -3,13 +3,17 @@ package main
 // DESCRIPTION: show commit history across repositories
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"cli-go/_internal/ai"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/git"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 	"os"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -19,13 +23,21 @@ type Config struct {
 	Main    bool
 	All     bool
 	Days    int
+	Since   string
+	Until   string
 	Author  string
+	Grep    string
+	Path    string
 	JSON    bool
 }
 
 func main() {
 	config := parseFlags()
 
+	// Stop reading repositories on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Check for help command
 	args := flag.Args()
 	if len(args) > 0 && args[0] == "help" {
@@ -33,7 +45,7 @@ func main() {
 		io.LogInfo("Shows commit history with filtering by days and author")
 		io.LogInfo("Supports --single <path> (specific repo), --main (main repos), --all (all repos)")
 		io.LogInfo("Default: all repositories")
-		io.LogInfo("Flags: --days N (days to look back), --author 'name' (filter by author)")
+		io.LogInfo("Flags: --days N (days to look back), --since/--until YYYY-MM-DD, --author 'name', --grep 'pattern', --path 'dir,file'")
 		io.LogInfo("Output: JSON with commit history grouped by day")
 		return
 	}
@@ -42,25 +54,26 @@ func main() {
 	repoPaths, err := git.GetReposToProcess(config.Single, config.Main, config.All, "all")
 	ai.ExitIf(err, "failed to get git history")
 
-	// Calculate since time
-	since := time.Now().AddDate(0, 0, -config.Days)
+	opts, err := logOptions(config)
+	ai.ExitIf(err, "invalid filter")
+
+	// Get commits from all repositories in parallel; failed repos are reported, not fatal
+	results := git.RunParallel(ctx, repoPaths, git.PoolOptions{Label: "Reading history"}, func(ctx context.Context, repoPath string) ([]git.Commit, error) {
+		return git.GetCommits(ctx, repoPath, opts)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(results)
 
-	// Get commits from all repositories
 	var allCommits []git.Commit
-	for _, repoPath := range repoPaths {
-		commits, err := git.GetCommits(repoPath, since, config.Author)
-		if err != nil {
-			// Log warning but continue with other repos
-			io.LogWarning("Failed to get commits from %s: %v", repoPath, err)
-			continue
-		}
-		allCommits = append(allCommits, commits...)
+	for _, result := range results {
+		allCommits = append(allCommits, result.Value...)
 	}
 
 	if len(allCommits) == 0 {
 		io.LogError("No commits found")
 		os.Exit(1)
 	}
+	sort.SliceStable(allCommits, func(i, j int) bool { return allCommits[i].Date.After(allCommits[j].Date) })
 
 	// Format and output results
 	if config.JSON {
@@ -72,6 +85,33 @@ func main() {
 	}
 }
 
+// logOptions builds the git log filters; --since overrides --days
+func logOptions(config Config) (git.LogOptions, error) {
+	opts := git.LogOptions{
+		Since:  time.Now().AddDate(0, 0, -config.Days),
+		Author: config.Author,
+		Grep:   config.Grep,
+	}
+	if config.Since != "" {
+		since, err := time.ParseInLocation("2006-01-02", config.Since, time.Local)
+		if err != nil {
+			return opts, fmt.Errorf("--since must be YYYY-MM-DD: %s", config.Since)
+		}
+		opts.Since = since
+	}
+	if config.Until != "" {
+		until, err := time.ParseInLocation("2006-01-02", config.Until, time.Local)
+		if err != nil {
+			return opts, fmt.Errorf("--until must be YYYY-MM-DD: %s", config.Until)
+		}
+		opts.Until = until.AddDate(0, 0, 1) // include the whole day
+	}
+	if config.Path != "" {
+		opts.Paths = strings.Split(config.Path, ",")
+	}
+	return opts, nil
+}
+
 func formatHistoryMarkdown(commits []git.Commit) string {
 	if len(commits) == 0 {
 		return "No commits found."
@@ -105,7 +145,7 @@ func formatHistoryMarkdown(commits []git.Commit) string {
 
 	for _, day := range days {
 		dayCommits := commitsByDay[day]
-		result = append(result, "### "+day+" ("+string(rune(len(dayCommits)))+" commits)")
+		result = append(result, fmt.Sprintf("### %s (%d commits)", day, len(dayCommits)))
 		result = append(result, "")
 
 		for _, commit := range dayCommits {
@@ -117,7 +157,7 @@ func formatHistoryMarkdown(commits []git.Commit) string {
 				result = append(result, "  - Tickets: "+joinStrings(commit.TicketIDs, ", "))
 			}
 			if commit.LinesAdded > 0 || commit.LinesDeleted > 0 {
-				result = append(result, "  - Changes: +"+string(rune(commit.LinesAdded))+" -"+string(rune(commit.LinesDeleted)))
+				result = append(result, fmt.Sprintf("  - Changes: %d files, +%d -%d", commit.FilesChanged, commit.LinesAdded, commit.LinesDeleted))
 			}
 			result = append(result, "")
 		}
@@ -156,7 +196,11 @@ func parseFlags() Config {
 	flag.BoolVar(&config.Main, "main", false, "Operate on main repositories (orbit + rasch-stack)")
 	flag.BoolVar(&config.All, "all", false, "Operate on all repositories from config")
 	flag.IntVar(&config.Days, "days", 7, "Number of days to look back")
+	flag.StringVar(&config.Since, "since", "", "Start date YYYY-MM-DD (overrides --days)")
+	flag.StringVar(&config.Until, "until", "", "End date YYYY-MM-DD (inclusive)")
 	flag.StringVar(&config.Author, "author", "", "Filter by author name")
+	flag.StringVar(&config.Grep, "grep", "", "Filter by commit message pattern (case-insensitive)")
+	flag.StringVar(&config.Path, "path", "", "Only commits touching these paths (comma-separated)")
 	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
 
 	flags.ReorderAndParse()
@@ -0,0 +1,181 @@
+package main
+
+// DESCRIPTION: rank files by change frequency, churn and complexity
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"cli-go/_internal/ai"
+	"cli-go/_internal/config"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Compact    bool
+	Single     string
+	Main       bool
+	All        bool
+	JSON       bool
+	Days       int
+	Since      string
+	Until      string
+	Top        int
+	Depth      int
+	MinCommits int
+	Exclude    string
+}
+
+// Report is the hotspot ranking for a time window
+type Report struct {
+	Since       time.Time         `json:"since"`
+	Until       time.Time         `json:"until"`
+	Files       []git.FileHotspot `json:"files"`
+	Directories []git.DirHotspot  `json:"directories"`
+}
+
+func main() {
+	cfg := parseFlags()
+
+	// Check for help command
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "help" {
+		io.LogInfo("ghotspots - Rank files by change frequency, churn and complexity")
+		io.LogInfo("Score = commits x (code lines + indentation complexity), relative to the highest in the set (0-100)")
+		io.LogInfo("Supports --single <path> (specific repo), --main (main repos), --all (all repos)")
+		io.LogInfo("Default: current repository")
+		io.LogInfo("Flags: --days N (default 90), --since/--until YYYY-MM-DD, --top N, --depth N, --min-commits N, --exclude 'a/,b/'")
+		io.LogInfo("Output: Formatted display (default) or JSON with --json flag")
+		return
+	}
+
+	repoPaths, err := git.GetReposToProcess(cfg.Single, cfg.Main, cfg.All, "pwd")
+	ai.ExitIf(err, "failed to get repositories to process")
+
+	report := Report{Since: time.Now().AddDate(0, 0, -cfg.Days)}
+	if cfg.Since != "" {
+		report.Since, err = time.ParseInLocation("2006-01-02", cfg.Since, time.Local)
+		ai.ExitIf(err, "--since must be YYYY-MM-DD")
+	}
+	var until time.Time
+	if cfg.Until != "" {
+		until, err = time.ParseInLocation("2006-01-02", cfg.Until, time.Local)
+		ai.ExitIf(err, "--until must be YYYY-MM-DD")
+		until = until.AddDate(0, 0, 1) // include the whole day
+		report.Until = until
+	} else {
+		report.Until = time.Now()
+	}
+
+	configData, err := config.LoadConfig()
+	ai.ExitIf(err, "failed to load config")
+	exclude := configData.Stats.Exclude
+	for _, pattern := range strings.Split(cfg.Exclude, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			exclude = append(exclude, pattern)
+		}
+	}
+
+	// Stop reading history on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	results := git.RunParallel(ctx, repoPaths, git.PoolOptions{Label: "Reading history"}, func(ctx context.Context, repoPath string) ([]git.FileHotspot, error) {
+		return git.GetFileChurn(ctx, repoPath, report.Since, until, exclude)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(results)
+
+	for _, result := range results {
+		for _, spot := range result.Value {
+			if spot.Commits >= cfg.MinCommits {
+				report.Files = append(report.Files, spot)
+			}
+		}
+	}
+	if len(report.Files) == 0 {
+		io.LogError("No changed files found since %s", report.Since.Format("2006-01-02"))
+		os.Exit(1)
+	}
+
+	// Rank across all repositories, then roll up before trimming to --top
+	git.RankHotspots(report.Files)
+	report.Directories = git.RollupHotspots(report.Files, cfg.Depth)
+	if cfg.Top > 0 {
+		report.Files = report.Files[:min(cfg.Top, len(report.Files))]
+		report.Directories = report.Directories[:min(cfg.Top, len(report.Directories))]
+	}
+
+	if cfg.JSON {
+		io.DirectOutput(report, *clip, *file, true)
+	} else {
+		io.DirectOutput(formatReport(report, len(repoPaths) > 1, cfg), *clip, *file, false)
+	}
+}
+
+func formatReport(report Report, multiRepo bool, cfg Config) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# 🔥 Hotspots %s – %s\n\n", report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02")))
+
+	name := func(repo, path string) string {
+		if multiRepo {
+			return filepath.Base(repo) + "/" + path
+		}
+		return path
+	}
+
+	output.WriteString("## Files\n\n")
+	output.WriteString("| # | File | Score | Commits | Authors | Churn | Lines | Complexity | Last change |\n")
+	output.WriteString("|--:|---|--:|--:|--:|--:|--:|--:|---|\n")
+	for i, spot := range report.Files {
+		output.WriteString(fmt.Sprintf("| %d | %s | %d | %d | %d | +%d -%d | %d | %d | %s |\n",
+			i+1, name(spot.Repository, spot.Path), spot.Score, spot.Commits, spot.Authors,
+			spot.Added, spot.Deleted, spot.Lines, spot.Complexity, spot.LastChanged.Format("2006-01-02")))
+	}
+
+	output.WriteString(fmt.Sprintf("\n## Directories (depth %d)\n\n", cfg.Depth))
+	output.WriteString("| # | Directory | Score | Files | Commits | Authors | Churn | Lines |\n")
+	output.WriteString("|--:|---|--:|--:|--:|--:|--:|--:|\n")
+	for i, dir := range report.Directories {
+		output.WriteString(fmt.Sprintf("| %d | %s | %d | %d | %d | %d | %d | %d |\n",
+			i+1, name(dir.Repository, dir.Path), dir.Score, dir.Files, dir.Commits, dir.Authors, dir.Churn, dir.Lines))
+	}
+
+	return output.String()
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
+	flag.StringVar(&config.Single, "single", "", "Operate on specific repository path")
+	flag.BoolVar(&config.Main, "main", false, "Operate on main repositories (orbit + rasch-stack)")
+	flag.BoolVar(&config.All, "all", false, "Operate on all repositories from config")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format (default: formatted)")
+	flag.IntVar(&config.Days, "days", 90, "Number of days to look back")
+	flag.StringVar(&config.Since, "since", "", "Start date YYYY-MM-DD (overrides --days)")
+	flag.StringVar(&config.Until, "until", "", "End date YYYY-MM-DD (inclusive)")
+	flag.IntVar(&config.Top, "top", 20, "Number of files and directories to show (0 for all)")
+	flag.IntVar(&config.Depth, "depth", 2, "Directory depth for the rollup")
+	flag.IntVar(&config.MinCommits, "min-commits", 2, "Only rank files changed in at least this many commits")
+	flag.StringVar(&config.Exclude, "exclude", "", "Comma-separated extra exclude patterns (added to stats.exclude)")
+
+	flags.ReorderAndParse()
+
+	if config.Depth < 1 {
+		config.Depth = 1
+	}
+	return config
+}
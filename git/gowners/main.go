@@ -0,0 +1,242 @@
+package main
+
+// DESCRIPTION: show code ownership per directory from git blame
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"cli-go/_internal/ai"
+	"cli-go/_internal/cache"
+	"cli-go/_internal/config"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Compact    bool
+	Single     string
+	Main       bool
+	All        bool
+	JSON       bool
+	Depth      int
+	Threshold  int
+	MinLines   int
+	Exclude    string
+	Codeowners string
+	NoCache    bool
+}
+
+func main() {
+	cfg := parseFlags()
+
+	// Check for help command
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "help" {
+		io.LogInfo("gowners - Show code ownership per directory from git blame")
+		io.LogInfo("Blames the tracked source files at HEAD (cached by blob hash) and sums surviving lines per author")
+		io.LogInfo("Supports --single <path> (specific repo), --main (main repos), --all (all repos)")
+		io.LogInfo("Default: current repository")
+		io.LogInfo("Flags: --depth N (directory depth, default 2), --threshold N (bus-factor warning %%, default 80), --min-lines N, --exclude 'a/,b/', --codeowners <file|->, --no-cache")
+		io.LogInfo("Output: Formatted display (default) or JSON with --json flag")
+		return
+	}
+
+	repoPaths, err := git.GetReposToProcess(cfg.Single, cfg.Main, cfg.All, "pwd")
+	ai.ExitIf(err, "failed to get repositories to process")
+	if cfg.Codeowners != "" && len(repoPaths) != 1 {
+		io.LogError("--codeowners needs a single repository")
+		os.Exit(1)
+	}
+
+	configData, err := config.LoadConfig()
+	ai.ExitIf(err, "failed to load config")
+	exclude := configData.Stats.Exclude
+	for _, pattern := range strings.Split(cfg.Exclude, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			exclude = append(exclude, pattern)
+		}
+	}
+
+	var store *cache.Store
+	if !cfg.NoCache {
+		store, err = cache.New("blame")
+		if err != nil {
+			io.LogWarning("blame cache unavailable, blaming every file: %v", err)
+		}
+	}
+
+	// Stop blaming on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	var reports []git.RepoOwnership
+	for _, repoPath := range repoPaths {
+		report, err := ownership(ctx, repoPath, exclude, store, cfg)
+		ai.ExitIf(ctx.Err(), "cancelled")
+		if err != nil {
+			io.LogWarning("%s: %v", repoPath, err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 {
+		io.LogError("No ownership data found")
+		os.Exit(1)
+	}
+
+	if cfg.Codeowners != "" {
+		content := formatCodeowners(reports[0], cfg.Depth)
+		if cfg.Codeowners == "-" {
+			fmt.Print(content)
+			return
+		}
+		ai.ExitIf(os.WriteFile(cfg.Codeowners, []byte(content), 0644), "failed to write CODEOWNERS")
+		io.LogInfo("Written CODEOWNERS to %s", cfg.Codeowners)
+		return
+	}
+
+	if cfg.JSON {
+		io.DirectOutput(reports, *clip, *file, true)
+	} else {
+		io.DirectOutput(formatOwnership(reports, cfg), *clip, *file, false)
+	}
+}
+
+// blameTimeout bounds a single file's blame; the pool's default is meant for a whole repository
+const blameTimeout = time.Minute
+
+// ownership blames every source file of a repository in parallel and aggregates lines per directory
+func ownership(ctx context.Context, repoPath string, exclude []string, store *cache.Store, cfg Config) (git.RepoOwnership, error) {
+	blobs, err := git.GetSourceBlobs(ctx, repoPath, exclude)
+	if err != nil {
+		return git.RepoOwnership{}, err
+	}
+	byPath := make(map[string]git.Blob, len(blobs))
+	paths := make([]string, 0, len(blobs))
+	for _, blob := range blobs {
+		byPath[blob.Path] = blob
+		paths = append(paths, blob.Path)
+	}
+
+	label := "Blaming " + filepath.Base(repoPath)
+	results := git.RunParallel(ctx, paths, git.PoolOptions{Label: label, Timeout: blameTimeout}, func(ctx context.Context, path string) ([]git.AuthorLines, error) {
+		return git.BlameBlob(ctx, repoPath, byPath[path], store)
+	})
+	if failed := git.Failures(results); len(failed) > 0 {
+		io.LogWarning("%s: %d of %d files could not be blamed (first: %v)", filepath.Base(repoPath), len(failed), len(results), failed[0].Err)
+	}
+
+	files := make(map[string][]git.AuthorLines, len(results))
+	for _, result := range results {
+		if result.Err == nil {
+			files[result.Repo] = result.Value
+		}
+	}
+	return git.AggregateOwnership(repoPath, files, git.OwnershipOptions{Depth: cfg.Depth, Threshold: cfg.Threshold, MinLines: cfg.MinLines}), nil
+}
+
+func formatOwnership(reports []git.RepoOwnership, cfg Config) string {
+	var output strings.Builder
+
+	for _, report := range reports {
+		output.WriteString(fmt.Sprintf("# 👥 Code Ownership: %s\n\n", filepath.Base(report.Repository)))
+		output.WriteString(fmt.Sprintf("%d files, %d lines, %d authors, bus factor %d\n\n", report.Files, report.Lines, len(report.Authors), report.BusFactor))
+
+		output.WriteString("## Top Authors\n\n")
+		output.WriteString("| Author | Lines | % |\n|---|--:|--:|\n")
+		for i, owner := range report.Authors {
+			if i == 10 {
+				break
+			}
+			output.WriteString(fmt.Sprintf("| %s | %d | %d%% |\n", owner.Name, owner.Lines, owner.Percent))
+		}
+
+		output.WriteString(fmt.Sprintf("\n## Directories (depth %d)\n\n", cfg.Depth))
+		output.WriteString("| Directory | Files | Lines | Owner | Share | Next | Bus factor |\n|---|--:|--:|---|--:|---|--:|\n")
+		for _, dir := range report.Directories {
+			owner, share, next := "-", "-", "-"
+			if len(dir.Owners) > 0 {
+				owner, share = dir.Owners[0].Name, fmt.Sprintf("%d%%", dir.Owners[0].Percent)
+			}
+			if len(dir.Owners) > 1 {
+				next = fmt.Sprintf("%s (%d%%)", dir.Owners[1].Name, dir.Owners[1].Percent)
+			}
+			output.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %s | %s | %d |\n", dir.Path, dir.Files, dir.Lines, owner, share, next, dir.BusFactor))
+		}
+
+		if len(report.Warnings) > 0 {
+			output.WriteString(fmt.Sprintf("\n## ⚠️ Bus Factor Warnings (≥%d%% by one author, ≥%d lines)\n\n", cfg.Threshold, cfg.MinLines))
+			for _, warning := range report.Warnings {
+				output.WriteString("- " + warning + "\n")
+			}
+		}
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
+
+// codeownersShare is the minimum share of a directory an author needs to be listed in CODEOWNERS
+const codeownersShare = 20
+
+// formatCodeowners writes one rule per directory listing up to three authors (by email) owning at least 20% of it
+func formatCodeowners(report git.RepoOwnership, depth int) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("# Generated by gowners from git blame on %s (directory depth %d).\n", time.Now().Format("2006-01-02"), depth))
+	output.WriteString("# Later rules take precedence; replace emails with GitHub handles or teams where needed.\n\n")
+
+	for _, dir := range report.Directories {
+		var owners []string
+		for _, owner := range dir.Owners {
+			if len(owners) == 3 || owner.Percent < codeownersShare {
+				break
+			}
+			owners = append(owners, owner.Email)
+		}
+		if len(owners) == 0 {
+			continue
+		}
+
+		pattern := "/" + dir.Path + "/"
+		if dir.Path == "." {
+			pattern = "/*"
+		}
+		output.WriteString(fmt.Sprintf("%s %s\n", pattern, strings.Join(owners, " ")))
+	}
+	return output.String()
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
+	flag.StringVar(&config.Single, "single", "", "Operate on specific repository path")
+	flag.BoolVar(&config.Main, "main", false, "Operate on main repositories (orbit + rasch-stack)")
+	flag.BoolVar(&config.All, "all", false, "Operate on all repositories from config")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format (default: formatted)")
+	flag.IntVar(&config.Depth, "depth", 2, "Directory depth to aggregate ownership at")
+	flag.IntVar(&config.Threshold, "threshold", 80, "Warn when one author owns at least this % of a directory")
+	flag.IntVar(&config.MinLines, "min-lines", 200, "Only warn for directories with at least this many lines")
+	flag.StringVar(&config.Exclude, "exclude", "", "Comma-separated extra exclude patterns (added to stats.exclude)")
+	flag.StringVar(&config.Codeowners, "codeowners", "", "Write a CODEOWNERS file to this path ('-' for stdout)")
+	flag.BoolVar(&config.NoCache, "no-cache", false, "Blame every file instead of using the blame cache")
+
+	flags.ReorderAndParse()
+
+	if config.Depth < 1 {
+		config.Depth = 1
+	}
+	return config
+}
@@ -3,12 +3,14 @@ package main
 // DESCRIPTION: search for PRs by ticket ID
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"cli-go/_internal/ai"
 	"cli-go/_internal/config"
 	"cli-go/_internal/custom"
 	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
 	"cli-go/_internal/github"
 	"cli-go/_internal/io"
 	"cli-go/_internal/sys"
@@ -28,6 +30,10 @@ type Config struct {
 func main() {
 	cfg := parseFlags()
 
+	// Cancel in-flight gh calls on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Check for help command
 	args := flag.Args()
 	if len(args) > 0 && args[0] == "help" {
@@ -71,28 +77,11 @@ func main() {
 		}
 	}
 
-	// Search main repos first
-	for _, repoConfig := range mainRepos {
-		query := fmt.Sprintf("%s in:title,body", normalizedTicketID)
-		prs, err := github.SearchPRsByQuery(repoConfig.Owner, repoConfig.Repo, query)
-		if err != nil {
-			io.LogWarning("Failed to search %s/%s: %v", repoConfig.Owner, repoConfig.Repo, err)
-			continue
-		}
-		allPRs = append(allPRs, prs...)
-	}
-
-	// If no PRs found in main repos, search all repos
+	// Search main repos first, then all repos if nothing was found
+	query := fmt.Sprintf("%s in:title,body", normalizedTicketID)
+	allPRs = searchRepos(ctx, mainRepos, query)
 	if len(allPRs) == 0 {
-		for _, repoConfig := range configData.Repositories {
-			query := fmt.Sprintf("%s in:title,body", normalizedTicketID)
-			prs, err := github.SearchPRsByQuery(repoConfig.Owner, repoConfig.Repo, query)
-			if err != nil {
-				io.LogWarning("Failed to search %s/%s: %v", repoConfig.Owner, repoConfig.Repo, err)
-				continue
-			}
-			allPRs = append(allPRs, prs...)
-		}
+		allPRs = searchRepos(ctx, configData.Repositories, query)
 	}
 
 	if len(allPRs) == 0 {
@@ -137,6 +126,22 @@ func parseFlags() Config {
 	return config
 }
 
+// searchRepos runs the PR search in parallel and returns the PRs in repository order
+func searchRepos(ctx context.Context, repos []config.RepoConfig, query string) []github.PR {
+	results := git.RunParallel(ctx, github.RepoSlugs(repos), git.PoolOptions{Label: "Searching PRs"}, func(ctx context.Context, slug string) ([]github.PR, error) {
+		owner, repo := github.SplitSlug(slug)
+		return github.SearchPRsByQuery(ctx, owner, repo, query)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(results)
+
+	var prs []github.PR
+	for _, result := range results {
+		prs = append(prs, result.Value...)
+	}
+	return prs
+}
+
 // formatPRs formats a list of PRs for display
 func formatPRs(prs []github.PR, jsonFormat bool) string {
 	if jsonFormat {
@@ -0,0 +1,249 @@
+package main
+
+// DESCRIPTION: delete merged, gone and stale branches across repositories
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/github"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+type Config struct {
+	Compact  bool
+	Single   string
+	Main     bool
+	All      bool
+	JSON     bool
+	Days     int
+	Remote   bool
+	Delete   bool
+	Yes      bool
+	NoGitHub bool
+	Inactive bool // --include-inactive
+}
+
+// Row is a prune candidate with its PR and what happened to it
+type Row struct {
+	git.PruneCandidate
+	PR      *github.PR `json:"pr,omitempty"`
+	Deleted bool       `json:"deleted"`
+	Error   string     `json:"error,omitempty"`
+}
+
+func main() {
+	cfg := parseFlags()
+
+	// Check for help command
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "help" {
+		io.LogInfo("gprune - Delete merged, gone and stale branches across repositories")
+		io.LogInfo("Finds branches merged into the main branch (or with a merged PR), with their upstream gone, or inactive for --days")
+		io.LogInfo("Never touches main, master, develop, the configured main branch or the current branch")
+		io.LogInfo("Default: all configured repositories; --single <path>, --main (main repos)")
+		io.LogInfo("Flags: --days N (default 90, 0 disables), --remote (also branches on origin), --no-github (skip PR lookup)")
+		io.LogInfo("Dry run by default; --delete asks per branch, --delete --yes deletes all merged and gone branches")
+		io.LogInfo("Branches that are only inactive need --include-inactive and are always asked for, even with --yes")
+		return
+	}
+
+	repoPaths, err := git.GetReposToProcess(cfg.Single, cfg.Main, cfg.All, "all")
+	ai.ExitIf(err, "failed to get repositories to process")
+
+	if cfg.Delete && !cfg.Yes && !term.IsTerminal(int(os.Stdin.Fd())) {
+		ai.LogError("--delete asks for confirmation on a terminal, use --yes to delete without asking")
+		os.Exit(1)
+	}
+
+	// Cancel in-flight git and gh calls on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	results := git.RunParallel(ctx, repoPaths, git.PoolOptions{Label: "Checking branches"}, func(ctx context.Context, repoPath string) ([]Row, error) {
+		return findRows(ctx, repoPath, cfg)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(results)
+
+	var rows []Row
+	for _, result := range results {
+		rows = append(rows, result.Value...)
+	}
+	if len(rows) == 0 {
+		io.LogSuccess("No branches to prune")
+		return
+	}
+
+	if !cfg.Delete {
+		if cfg.JSON {
+			io.DirectOutput(rows, *clip, *file, true)
+			return
+		}
+		io.DirectOutput(formatTable(rows, len(repoPaths) > 1), *clip, *file, false)
+		io.LogInfo("Dry run: use --delete to delete them (asks per branch, --yes for all)")
+		return
+	}
+
+	if !cfg.JSON {
+		io.DirectOutput(formatTable(rows, len(repoPaths) > 1), false, "", false)
+	}
+	deleted := deleteRows(rows, cfg.Yes, cfg.Inactive)
+	if cfg.JSON {
+		io.DirectOutput(rows, *clip, *file, true)
+		return
+	}
+	io.LogSuccess("Deleted %d of %d branches", deleted, len(rows))
+}
+
+// findRows finds the prune candidates of a repository and matches them with their PRs
+func findRows(ctx context.Context, repoPath string, cfg Config) ([]Row, error) {
+	var prs map[string]github.PR
+	if !cfg.NoGitHub {
+		var err error
+		if prs, err = git.ListBranchPRs(ctx, repoPath); err != nil {
+			io.LogWarning("%s: no PR state: %v", filepath.Base(repoPath), err)
+		}
+	}
+
+	options := git.PruneOptions{InactiveDays: cfg.Days, Remote: cfg.Remote, MergedPRs: make(map[string]bool)}
+	for head, pr := range prs {
+		if pr.State == "MERGED" {
+			options.MergedPRs[head] = true
+		}
+	}
+	candidates, err := git.FindPruneCandidates(ctx, repoPath, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, candidate := range candidates {
+		row := Row{PruneCandidate: candidate}
+		if pr, ok := prs[candidate.Branch]; ok {
+			// Deleting the head branch of an open PR on origin closes the PR
+			if candidate.Remote && pr.State == "OPEN" {
+				continue
+			}
+			row.PR = &pr
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// deleteRows deletes the rows, asking for each one unless yes; inactive-only rows are skipped
+// unless includeInactive and then always asked for. Returns how many were deleted.
+func deleteRows(rows []Row, yes, includeInactive bool) int {
+	reader := bufio.NewReader(os.Stdin)
+	interactive := term.IsTerminal(int(os.Stdin.Fd()))
+	deleted := 0
+	for i := range rows {
+		row := &rows[i]
+		inactiveOnly := row.InactiveOnly()
+		if inactiveOnly && (!includeInactive || !interactive) {
+			row.Error = "only inactive: needs --include-inactive and a confirmation on a terminal"
+			continue
+		}
+		if !yes || inactiveOnly {
+			fmt.Fprintf(os.Stderr, "Delete %s in %s (%s)? [y/N/a(ll)/q(uit)] ", branchName(*row), filepath.Base(row.Repository), strings.Join(row.Reasons, ", "))
+			answer, _ := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "y", "yes":
+			case "a", "all":
+				yes = true
+			case "q", "quit":
+				return deleted
+			default:
+				continue
+			}
+		}
+
+		if err := git.DeletePruneCandidate(row.PruneCandidate); err != nil {
+			row.Error = err.Error()
+			io.LogWarning("%v", err)
+			continue
+		}
+		row.Deleted = true
+		deleted++
+		io.LogSuccess("Deleted %s in %s (was %s)", branchName(*row), filepath.Base(row.Repository), row.Commit)
+	}
+	return deleted
+}
+
+func formatTable(rows []Row, multiRepo bool) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("## 🌿 %d branches to prune\n\n", len(rows)))
+	output.WriteString("| Repository | Branch | Why | Last commit | Author | PR |\n")
+	output.WriteString("|---|---|---|---|---|---|\n")
+	for _, row := range rows {
+		repo := ""
+		if multiRepo {
+			repo = filepath.Base(row.Repository)
+		}
+		lastCommit := fmt.Sprintf("%s (%s ago) %s", row.LastCommit.Format("2006-01-02"),
+			io.FormatDuration(time.Since(row.LastCommit)), git.TruncateString(row.Subject, 40))
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			repo, branchName(row), strings.Join(row.Reasons, ", "), escape(lastCommit), escape(row.Author), prState(row.PR)))
+	}
+	return output.String()
+}
+
+func branchName(row Row) string {
+	if row.Remote {
+		return "origin/" + row.Branch
+	}
+	return row.Branch
+}
+
+func prState(pr *github.PR) string {
+	if pr == nil {
+		return "–"
+	}
+	state := strings.ToLower(pr.State)
+	if pr.IsDraft && pr.State == "OPEN" {
+		state = "draft"
+	}
+	return fmt.Sprintf("#%d %s", pr.Number, state)
+}
+
+// escape keeps pipes in commit subjects from breaking the table
+func escape(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
+	flag.StringVar(&config.Single, "single", "", "Operate on specific repository path")
+	flag.BoolVar(&config.Main, "main", false, "Operate on main repositories (orbit + rasch-stack)")
+	flag.BoolVar(&config.All, "all", false, "Operate on all repositories from config (default)")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
+	flag.IntVar(&config.Days, "days", 90, "Branches without commits for this many days are stale (0 disables)")
+	flag.BoolVar(&config.Remote, "remote", false, "Also prune branches on origin")
+	flag.BoolVar(&config.Delete, "delete", false, "Delete the branches (asks per branch unless --yes)")
+	flag.BoolVar(&config.Yes, "yes", false, "Delete without asking")
+	flag.BoolVar(&config.NoGitHub, "no-github", false, "Skip the PR lookup")
+	flag.BoolVar(&config.Inactive, "include-inactive", false, "Also delete branches that are only inactive (asked per branch, even with --yes)")
+
+	flags.ReorderAndParse()
+
+	return config
+}
@@ -0,0 +1,298 @@
+package main
+
+// DESCRIPTION: generate release notes from commits and Jira tickets
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"cli-go/_internal/jira"
+	"cli-go/_internal/sys"
+)
+
+type Config struct {
+	Single     string
+	JSON       bool
+	NoJira     bool
+	AI         bool
+	Provider   string
+	Persona    string
+	NoRedact   bool
+	Generation *ai.GenerationFlags
+}
+
+// ReleaseNotes is the --json output
+type ReleaseNotes struct {
+	Repository string        `json:"repository"`
+	Range      string        `json:"range"`
+	Tickets    []TicketNotes `json:"tickets"`
+	Other      []git.Commit  `json:"other"`
+	Polished   string        `json:"polished,omitempty"`
+}
+
+// TicketNotes groups the commits of one ticket with its Jira details
+type TicketNotes struct {
+	Key     string       `json:"key"`
+	Summary string       `json:"summary,omitempty"`
+	Type    string       `json:"type,omitempty"`
+	Status  string       `json:"status,omitempty"`
+	URL     string       `json:"url,omitempty"`
+	Commits []git.Commit `json:"commits"`
+}
+
+// sections orders Jira issue types into release note sections
+var sections = []struct {
+	title string
+	types []string
+}{
+	{"✨ Features", []string{"story", "feature", "new feature", "epic"}},
+	{"🐛 Fixes", []string{"bug", "defect", "incident"}},
+	{"🔧 Improvements", []string{"task", "sub-task", "subtask", "improvement", "technical task", "spike"}},
+}
+
+const defaultPolishPrompt = `You are writing release notes for end users and stakeholders.
+Rewrite the given markdown release notes so each ticket reads as one clear, user-facing sentence.
+Keep the sections, ticket keys and the link list exactly as they are. Do not invent changes.
+Return only the markdown.`
+
+func main() {
+	config := parseFlags()
+
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	repoPath := config.Single
+	if repoPath == "" {
+		root, err := git.GetGitRoot()
+		ai.ExitIf(err, "failed to find repository")
+		repoPath = root
+	} else if !git.IsGitRepoAtPath(repoPath) {
+		ai.LogError("path is not a git repository: %s", repoPath)
+		os.Exit(1)
+	}
+
+	revRange, err := resolveRange(repoPath, flag.Args())
+	ai.ExitIf(err, "failed to resolve range")
+
+	commits, err := git.GetCommitsInRange(ctx, repoPath, revRange)
+	ai.ExitIf(err, "failed to get commits")
+	if len(commits) == 0 {
+		io.LogError("No commits found in %s", revRange)
+		os.Exit(1)
+	}
+
+	notes := groupByTicket(commits)
+	notes.Repository = repoPath
+	notes.Range = revRange
+
+	if !config.NoJira {
+		enrichFromJira(ctx, notes)
+	}
+
+	markdown := formatMarkdown(notes, repoPath)
+	if config.AI {
+		notes.Polished = polish(ctx, config, markdown)
+		markdown = notes.Polished
+	}
+
+	if config.JSON {
+		io.DirectOutput(notes, *clip, *file, true)
+	} else {
+		io.DirectOutput(markdown, *clip, *file, false)
+	}
+}
+
+// resolveRange accepts "a..b", "a b", a single start ref (to HEAD) or nothing (the latest release)
+func resolveRange(repoPath string, args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		revRange, err := git.GetReleaseRange(repoPath)
+		if err != nil {
+			return "", fmt.Errorf("%v (pass a range like v1.2.0..v1.3.0)", err)
+		}
+		return revRange, nil
+	case 1:
+		if strings.Contains(args[0], "..") {
+			return args[0], nil
+		}
+		return args[0] + "..HEAD", nil
+	case 2:
+		return args[0] + ".." + args[1], nil
+	}
+	return "", fmt.Errorf("expected a range, got %d arguments", len(args))
+}
+
+// groupByTicket assigns every commit to each ticket it mentions; commits without tickets go to Other
+func groupByTicket(commits []git.Commit) *ReleaseNotes {
+	notes := &ReleaseNotes{}
+	index := make(map[string]int)
+
+	for _, commit := range commits {
+		if len(commit.TicketIDs) == 0 {
+			notes.Other = append(notes.Other, commit)
+			continue
+		}
+		for _, key := range commit.TicketIDs {
+			i, ok := index[key]
+			if !ok {
+				i = len(notes.Tickets)
+				index[key] = i
+				notes.Tickets = append(notes.Tickets, TicketNotes{Key: key})
+			}
+			notes.Tickets[i].Commits = append(notes.Tickets[i].Commits, commit)
+		}
+	}
+
+	sort.SliceStable(notes.Tickets, func(i, j int) bool { return notes.Tickets[i].Key < notes.Tickets[j].Key })
+	return notes
+}
+
+// enrichFromJira adds summary, type, status and link to each ticket; Jira failures only warn
+func enrichFromJira(ctx context.Context, notes *ReleaseNotes) {
+	if len(notes.Tickets) == 0 {
+		return
+	}
+
+	jiraConfig, apiToken, err := jira.LoadJiraConfig()
+	if err != nil {
+		io.LogWarning("Skipping Jira details: %v", err)
+		return
+	}
+	client := jira.NewClient(jiraConfig.BaseURL, jiraConfig.Email, apiToken, jiraConfig.DefaultProject)
+
+	for i := range notes.Tickets {
+		ticket := &notes.Tickets[i]
+		ticket.URL = jira.IssueURL(jiraConfig.BaseURL, ticket.Key)
+
+		issue, err := client.GetIssue(ctx, ticket.Key)
+		if err != nil {
+			ai.ExitIf(ctx.Err(), "cancelled")
+			io.LogWarning("Failed to fetch %s: %v", ticket.Key, err)
+			continue
+		}
+		ticket.Summary = issue.Fields.Summary
+		ticket.Type = issue.Fields.IssueType.Name
+		ticket.Status = issue.Fields.Status.Name
+	}
+}
+
+// sectionFor returns the section title for a Jira issue type
+func sectionFor(issueType string) string {
+	if issueType == "" {
+		return "🎫 Tickets"
+	}
+	for _, section := range sections {
+		if git.Contains(section.types, strings.ToLower(issueType)) {
+			return section.title
+		}
+	}
+	return "📦 Other Tickets"
+}
+
+// formatMarkdown renders tickets by section, commits without tickets, and the ticket link list
+func formatMarkdown(notes *ReleaseNotes, repoPath string) string {
+	remoteURL, _ := git.GetRemoteURL(repoPath)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Release Notes (%s)\n", notes.Range)
+
+	bySection := make(map[string][]TicketNotes)
+	for _, ticket := range notes.Tickets {
+		title := sectionFor(ticket.Type)
+		bySection[title] = append(bySection[title], ticket)
+	}
+
+	titles := []string{}
+	for _, section := range sections {
+		titles = append(titles, section.title)
+	}
+	titles = append(titles, "📦 Other Tickets", "🎫 Tickets")
+
+	for _, title := range titles {
+		tickets := bySection[title]
+		if len(tickets) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", title)
+		for _, ticket := range tickets {
+			// Without Jira the ticket's first commit (git log lists newest first) describes it best
+			summary := ticket.Summary
+			if summary == "" {
+				summary = ticket.Commits[len(ticket.Commits)-1].Message
+			}
+			fmt.Fprintf(&b, "- [%s] %s (%s)\n", ticket.Key, summary, io.Plural(len(ticket.Commits), "commit"))
+		}
+	}
+
+	if len(notes.Other) > 0 {
+		b.WriteString("\n## 🧹 Other Changes\n\n")
+		for _, commit := range notes.Other {
+			hash := commit.Hash[:8]
+			if remoteURL != "" {
+				hash = fmt.Sprintf("[%s](%s/commit/%s)", hash, remoteURL, commit.Hash)
+			}
+			fmt.Fprintf(&b, "- %s %s (%s)\n", hash, commit.Message, commit.Author)
+		}
+	}
+
+	// Reference-style links make the [KEY] mentions above clickable
+	var links []string
+	for _, ticket := range notes.Tickets {
+		if ticket.URL != "" {
+			links = append(links, fmt.Sprintf("[%s]: %s", ticket.Key, ticket.URL))
+		}
+	}
+	if len(links) > 0 {
+		fmt.Fprintf(&b, "\n## 🔗 Tickets\n\n%s\n", strings.Join(links, "\n"))
+	}
+
+	return b.String()
+}
+
+// polish asks the AI to rewrite the notes for readers, keeping structure and links
+func polish(ctx context.Context, config Config, markdown string) string {
+	client, err := ai.NewToolClient(ai.ToolClientOptions{
+		Tool:          "grelnotes",
+		Provider:      config.Provider,
+		Persona:       config.Persona,
+		DefaultPrompt: defaultPolishPrompt,
+		NoRedact:      config.NoRedact,
+		Generation:    config.Generation,
+	})
+	ai.ExitIf(err, "failed to create AI client")
+
+	io.LogInfo("✍️  Polishing release notes with %s", client.GetModel())
+	response, err := client.SendMessage(ctx, markdown)
+	ai.ExitIfAPI(err, "failed to polish release notes", config.JSON)
+	return strings.TrimSpace(response)
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.StringVar(&config.Single, "single", "", "Repository path (default: current repository)")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
+	flag.BoolVar(&config.NoJira, "no-jira", false, "Don't fetch ticket details from Jira")
+	flag.BoolVar(&config.AI, "ai", false, "Polish the release notes with AI")
+	flag.StringVar(&config.Provider, "provider", "openai", "AI provider for --ai: openai, anthropic, haiku, google, xai")
+	flag.StringVar(&config.Persona, "persona", "", "Persona used as system prompt for --ai")
+	flag.BoolVar(&config.NoRedact, "no-redact", false, "Send content without masking secrets")
+	config.Generation = ai.RegisterGenerationFlags()
+
+	flags.ReorderAndParse()
+
+	return config
+}
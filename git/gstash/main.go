@@ -0,0 +1,161 @@
+package main
+
+// DESCRIPTION: browse and manage git stashes
+
+import (
+	"flag"
+	"fmt"
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"os"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Compact   bool
+	JSON      bool
+	Message   string
+	Untracked bool
+}
+
+// Result is the outcome of a stash action
+type Result struct {
+	Action string     `json:"action"`
+	Stash  *git.Stash `json:"stash,omitempty"`
+	Branch string     `json:"branch,omitempty"`
+	Info   string     `json:"message"`
+}
+
+func main() {
+	cfg := parseFlags()
+
+	args := flag.Args()
+	command := "list"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	query := strings.Join(args, " ")
+
+	switch command {
+	case "help":
+		io.LogInfo("gstash - Browse and manage git stashes")
+		io.LogInfo("gstash [list]                      List stashes with branch, age and changed files")
+		io.LogInfo("gstash show [n|search]             Show the diff of a stash (default: latest)")
+		io.LogInfo("gstash apply|pop|drop [n|search]   Apply, pop or drop a stash by index or message search")
+		io.LogInfo("gstash push [paths...]             Stash changes (--untracked, --message 'text', only paths when given)")
+		io.LogInfo("gstash branch <n|search> <name>    Create a branch from a stash and drop the stash")
+		io.LogInfo("Output: Formatted display (default) or JSON with --json flag")
+	case "list":
+		stashes, err := git.ListStashes()
+		ai.ExitIf(err, "failed to list stashes")
+		if cfg.JSON {
+			io.DirectOutput(stashes, *clip, *file, true)
+		} else if len(stashes) == 0 {
+			io.LogInfo("no stashes")
+		} else {
+			io.DirectOutput(formatList(stashes), *clip, *file, false)
+		}
+	case "show":
+		stash := findStash(query)
+		patch, err := git.GetStashDiff(stash.Ref)
+		ai.ExitIf(err, "failed to show stash")
+		if cfg.JSON {
+			io.DirectOutput(stash, *clip, *file, true)
+		} else if *clip || *file != "" {
+			io.DirectOutput(patch, *clip, *file, false)
+		} else {
+			fmt.Printf("%s\n\n", io.FormatWithEmoji(fmt.Sprintf("%s on %s: %s", stash.Ref, stash.Branch, stash.Message), "file"))
+			fmt.Print(io.RenderUnifiedDiff(patch, io.UseColor()))
+		}
+	case "apply", "pop":
+		stash := findStash(query)
+		ai.ExitIf(git.ApplyStash(stash.Ref, command == "pop"), "failed to "+command+" stash")
+		verb := map[string]string{"apply": "Applied", "pop": "Popped"}[command]
+		output(cfg, Result{Action: command, Stash: stash, Info: fmt.Sprintf("%s %s: %s", verb, stash.Ref, stash.Message)})
+	case "drop":
+		stash := findStash(query)
+		ai.ExitIf(git.DropStash(stash.Ref), "failed to drop stash")
+		output(cfg, Result{Action: "drop", Stash: stash, Info: fmt.Sprintf("Dropped %s: %s (restore with: git stash store -m %q %s)",
+			stash.Ref, stash.Message, stash.Message, stash.Hash)})
+	case "push":
+		info, err := git.PushStash(git.StashOptions{Message: cfg.Message, Untracked: cfg.Untracked, Paths: args})
+		ai.ExitIf(err, "failed to create stash")
+		if !info.Stashed {
+			io.LogInfo("%s", info.Message)
+			return
+		}
+		output(cfg, Result{Action: "push", Info: "Stashed: " + info.Message})
+	case "branch":
+		if len(args) < 2 {
+			ai.LogError("Usage: gstash branch <n|search> <branch-name>")
+			os.Exit(1)
+		}
+		branch := args[len(args)-1]
+		stash := findStash(strings.Join(args[:len(args)-1], " "))
+		ai.ExitIf(git.StashToBranch(stash.Ref, branch), "failed to create branch from stash")
+		output(cfg, Result{Action: "branch", Stash: stash, Branch: branch, Info: fmt.Sprintf("Created branch %s from %s: %s", branch, stash.Ref, stash.Message)})
+	default:
+		ai.LogError("unknown command %q, see gstash help", command)
+		os.Exit(1)
+	}
+}
+
+func findStash(query string) *git.Stash {
+	stash, err := git.FindStash(query)
+	ai.ExitIf(err, "failed to find stash")
+	return stash
+}
+
+func output(cfg Config, result Result) {
+	if cfg.JSON {
+		io.DirectOutput(result, *clip, *file, true)
+	} else {
+		io.LogSuccess("%s", result.Info)
+	}
+}
+
+func formatList(stashes []git.Stash) string {
+	var output strings.Builder
+	output.WriteString("| # | Branch | Age | Files | Changes | Message |\n")
+	output.WriteString("|--:|---|---|---|--:|---|\n")
+	for _, stash := range stashes {
+		output.WriteString(fmt.Sprintf("| %d | %s | %s ago | %s | +%d -%d | %s |\n",
+			stash.Index, stash.Branch, io.FormatDuration(time.Since(stash.Date)), fileSummary(stash.Files),
+			stash.Added, stash.Deleted, strings.ReplaceAll(stash.Message, "|", "\\|")))
+	}
+	return output.String()
+}
+
+// fileSummary lists up to 3 file names and how many more there are
+func fileSummary(files []git.FileChange) string {
+	var names []string
+	for i, change := range files {
+		if i == 3 {
+			names = append(names, fmt.Sprintf("+%d more", len(files)-3))
+			break
+		}
+		names = append(names, change.Path)
+	}
+	return strings.Join(names, ", ")
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
+	flag.StringVar(&config.Message, "message", "", "Stash message for push (default: time and file names)")
+	flag.BoolVar(&config.Untracked, "untracked", false, "Include untracked files in push")
+
+	flags.ReorderAndParse()
+
+	return config
+}
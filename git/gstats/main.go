@@ -3,32 +3,40 @@ package main
 // DESCRIPTION: show file / LOC stats of repo
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"cli-go/_internal/ai"
+	"cli-go/_internal/config"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/git"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 )
 
 type Config struct {
-	Compact bool
-	Single  string
-	Main    bool
-	All     bool
-	JSON    bool
+	Compact          bool
+	Single           string
+	Main             bool
+	All              bool
+	JSON             bool
+	Exclude          string
+	IncludeGenerated bool
 }
 
 func main() {
-	config := parseFlags()
+	cfg := parseFlags()
 
 	// Check for help command
 	args := flag.Args()
 	if len(args) > 0 && args[0] == "help" {
 		io.LogInfo("gstats - Show file/LOC stats of repository")
-		io.LogInfo("Analyzes git-tracked files and provides statistics by extension")
+		io.LogInfo("Analyzes git-tracked files and provides statistics by extension and language")
+		io.LogInfo("Paths in stats.exclude (config.yml) and --exclude are skipped; generated/minified files are reported separately")
 		io.LogInfo("Supports --single <path> (specific repo), --main (main repos), --all (all repos)")
 		io.LogInfo("Default: current repository")
 		io.LogInfo("Output: Formatted display (default) or JSON with --json flag")
@@ -36,27 +44,49 @@ func main() {
 	}
 
 	// Determine which repositories to process
-	repoPaths, err := git.GetReposToProcess(config.Single, config.Main, config.All, "pwd")
+	repoPaths, err := git.GetReposToProcess(cfg.Single, cfg.Main, cfg.All, "pwd")
 	ai.ExitIf(err, "failed to get repositories to process")
 
+	configData, err := config.LoadConfig()
+	ai.ExitIf(err, "failed to load config")
+
+	opts := git.StatsOptions{Exclude: configData.Stats.Exclude, IncludeGenerated: cfg.IncludeGenerated}
+	for _, pattern := range strings.Split(cfg.Exclude, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			opts.Exclude = append(opts.Exclude, pattern)
+		}
+	}
+
+	// Stop scanning on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	results := git.RunParallel(ctx, repoPaths, git.PoolOptions{Label: "Scanning repositories"}, func(ctx context.Context, repoPath string) (*git.RepoStats, error) {
+		return git.GetRepoStatsWithOptions(ctx, repoPath, opts)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+
+	var repoStats []git.RepoStats
+	for _, r := range results {
+		if r.Err == nil {
+			repoStats = append(repoStats, *r.Value)
+		}
+	}
+	if len(repoPaths) == 1 {
+		ai.ExitIf(results[0].Err, "failed to get repo stats")
+	} else if git.ReportFailures(results) == len(results) {
+		io.LogError("failed to get stats for all repositories")
+		os.Exit(1)
+	}
+
 	var result interface{}
 	if len(repoPaths) == 1 {
-		// Single repo - use existing behavior
-		stats, err := git.GetRepoStats(repoPaths[0])
-		ai.ExitIf(err, "failed to get repo stats")
-		result = stats
+		result = &repoStats[0]
 	} else {
-		// Multiple repos - aggregate stats
-		// For multiple repos, we'll need to implement this
-		// For now, just use the first repo
-		stats, err := git.GetRepoStats(repoPaths[0])
-		ai.ExitIf(err, "failed to get repo stats")
-		repoStats := []git.RepoStats{*stats}
-		// No error to check here - this is just assignment
-		result = repoStats
+		result = git.AggregateRepoStats(repoStats)
 	}
 
-	if config.JSON {
+	if cfg.JSON {
 		io.DirectOutput(result, *clip, *file, true)
 	} else {
 		// Formatted output for better display
@@ -78,6 +108,8 @@ func parseFlags() Config {
 	flag.BoolVar(&config.Main, "main", false, "Operate on main repositories (orbit + rasch-stack)")
 	flag.BoolVar(&config.All, "all", false, "Operate on all repositories from config")
 	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format (default: formatted)")
+	flag.StringVar(&config.Exclude, "exclude", "", "Comma-separated extra exclude patterns (e.g. 'docs/,*.spec.ts')")
+	flag.BoolVar(&config.IncludeGenerated, "include-generated", false, "Count generated and minified files")
 
 	flags.ReorderAndParse()
 
@@ -120,47 +152,74 @@ func formatStatsOutput(result interface{}) string {
 			}
 		}
 
+		if len(stats.Languages) > 0 {
+			output.WriteString("\n🗂️  Lines by Language:\n\n")
+			writeLanguages(&output, stats.Languages)
+		}
+
+		writeGenerated(&output, *stats)
+
 		// Tickets if any
 		if len(stats.Tickets) > 0 {
 			output.WriteString(fmt.Sprintf("\n%s\n", io.FormatWithEmoji(fmt.Sprintf("Recent Tickets: %s", strings.Join(stats.Tickets, ", ")), "ticket")))
 		}
-	} else if repoStats, ok := result.([]git.RepoStats); ok {
-		// Handle multiple repo stats
+	} else if multi, ok := result.(git.MultiRepoStats); ok {
 		output.WriteString(io.FormatWithEmoji("Multi-Repository Statistics", "cache") + "\n")
 		output.WriteString("=" + strings.Repeat("=", 35) + "\n\n")
 
-		totalFiles := 0
-		totalLines := 0
-		totalBranches := 0
-		totalCommits := 0
-		totalAuthors := 0
-
-		for i, stats := range repoStats {
-			output.WriteString(fmt.Sprintf("%s\n", io.FormatWithEmoji(fmt.Sprintf("Repository %d:", i+1), "file")))
-			output.WriteString(fmt.Sprintf("  %s | %s | %s\n",
-				io.FormatWithEmoji(fmt.Sprintf("Files: %d", stats.TotalFiles), "file"),
-				io.FormatWithEmoji(fmt.Sprintf("Lines: %d", stats.TotalLines), "file"),
-				io.FormatWithEmoji(fmt.Sprintf("Branches: %d", stats.Branches), "branches")))
-			output.WriteString(fmt.Sprintf("  %s | %s\n\n",
-				io.FormatWithEmoji(fmt.Sprintf("Commits: %d", stats.Commits), "commits"),
-				io.FormatWithEmoji(fmt.Sprintf("Authors: %d", stats.Authors), "user")))
-
-			totalFiles += stats.TotalFiles
-			totalLines += stats.TotalLines
-			totalBranches += stats.Branches
-			totalCommits += stats.Commits
-			totalAuthors += stats.Authors
+		// Comparison table: one row per repository, then the combined totals
+		output.WriteString("| Repository | Files | Lines | Commits | Authors | Branches | Top language |\n")
+		output.WriteString("|---|--:|--:|--:|--:|--:|---|\n")
+		for _, stats := range multi.Repos {
+			writeRepoRow(&output, filepath.Base(stats.Repository), stats)
 		}
+		writeRepoRow(&output, "Combined", multi.Combined)
 
-		output.WriteString(io.FormatWithEmoji("Totals:", "cache") + "\n")
-		output.WriteString(fmt.Sprintf("  %s | %s | %s\n",
-			io.FormatWithEmoji(fmt.Sprintf("Files: %d", totalFiles), "file"),
-			io.FormatWithEmoji(fmt.Sprintf("Lines: %d", totalLines), "file"),
-			io.FormatWithEmoji(fmt.Sprintf("Branches: %d", totalBranches), "branches")))
-		output.WriteString(fmt.Sprintf("  %s | %s\n",
-			io.FormatWithEmoji(fmt.Sprintf("Commits: %d", totalCommits), "commits"),
-			io.FormatWithEmoji(fmt.Sprintf("Authors: %d", totalAuthors), "user")))
+		if len(multi.Combined.Languages) > 0 {
+			output.WriteString("\n🗂️  Lines by Language (combined):\n\n")
+			writeLanguages(&output, multi.Combined.Languages)
+		}
+		writeGenerated(&output, multi.Combined)
 	}
 
 	return output.String()
 }
+
+// writeRepoRow writes one row of the repository comparison table
+func writeRepoRow(output *strings.Builder, name string, stats git.RepoStats) {
+	top := "-"
+	if len(stats.Languages) > 0 {
+		top = fmt.Sprintf("%s (%d%%)", stats.Languages[0].Language, stats.Languages[0].Percent)
+	}
+	output.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %s |\n",
+		name, stats.TotalFiles, stats.TotalLines, stats.Commits, stats.Authors, stats.Branches, top))
+}
+
+// writeLanguages writes a table with one row per language and the extensions it groups
+func writeLanguages(output *strings.Builder, languages []git.LanguageStats) {
+	output.WriteString("| Language | Files | Lines | Code | Comments | Blank | % | Extensions |\n")
+	output.WriteString("|---|--:|--:|--:|--:|--:|--:|---|\n")
+	for _, language := range languages {
+		output.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d%% | %s |\n",
+			language.Language, language.Files, language.Lines, language.Code, language.Comments, language.Blank,
+			language.Percent, strings.Join(language.Extensions, ", ")))
+	}
+}
+
+// writeGenerated notes the generated/minified and excluded files that were left out of the totals
+func writeGenerated(output *strings.Builder, stats git.RepoStats) {
+	if len(stats.Generated) > 0 {
+		output.WriteString(fmt.Sprintf("\n⚙️  Skipped %d generated/minified files (%d lines, use --include-generated to count them):\n\n",
+			len(stats.Generated), stats.GeneratedLines))
+		for i, file := range stats.Generated {
+			if i == 10 {
+				output.WriteString(fmt.Sprintf("- ... and %d more\n", len(stats.Generated)-10))
+				break
+			}
+			output.WriteString(fmt.Sprintf("- %s\n", file))
+		}
+	}
+	if stats.Excluded > 0 {
+		output.WriteString(fmt.Sprintf("\n🚫 Excluded %d files matching stats.exclude / --exclude\n", stats.Excluded))
+	}
+}
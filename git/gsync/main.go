@@ -0,0 +1,146 @@
+package main
+
+// DESCRIPTION: fetch, fast-forward and report branches across repositories
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"cli-go/_internal/ai"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"path/filepath"
+	"strings"
+)
+
+type Config struct {
+	Compact bool
+	Single  string
+	Main    bool
+	All     bool
+	JSON    bool
+}
+
+// Report is the outcome of gsync across repositories
+type Report struct {
+	Repos  []git.SyncResult  `json:"repos"`
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+func main() {
+	cfg := parseFlags()
+
+	// Check for help command
+	args := flag.Args()
+	if len(args) > 0 && args[0] == "help" {
+		io.LogInfo("gsync - Fetch, fast-forward and report branches across repositories")
+		io.LogInfo("Per repository: git fetch --prune, fast-forward the main branch when it has no local commits,")
+		io.LogInfo("and list branches ahead of or behind their upstream; repositories with uncommitted changes are skipped")
+		io.LogInfo("Supports --single <path> (specific repo), --main (main repos), --all (all repos)")
+		io.LogInfo("Default: all repositories")
+		io.LogInfo("Output: Summary table (default) or JSON with --json flag")
+		return
+	}
+
+	repoPaths, err := git.GetReposToProcess(cfg.Single, cfg.Main, cfg.All, "all")
+	ai.ExitIf(err, "failed to get repositories to process")
+
+	// Stop syncing on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	results := git.RunParallel(ctx, repoPaths, git.PoolOptions{Label: "Syncing"}, func(ctx context.Context, repoPath string) (*git.SyncResult, error) {
+		return git.SyncRepo(ctx, repoPath)
+	})
+	ai.ExitIf(ctx.Err(), "cancelled")
+	git.ReportFailures(results)
+
+	report := Report{Repos: []git.SyncResult{}}
+	for _, result := range results {
+		if result.Err != nil {
+			if report.Failed == nil {
+				report.Failed = make(map[string]string)
+			}
+			report.Failed[result.Repo] = result.Error
+			continue
+		}
+		report.Repos = append(report.Repos, *result.Value)
+	}
+
+	if cfg.JSON {
+		io.DirectOutput(report, *clip, *file, true)
+		return
+	}
+	io.DirectOutput(formatReport(report), *clip, *file, false)
+}
+
+func formatReport(report Report) string {
+	var output strings.Builder
+	output.WriteString("## 🔄 Sync\n\n")
+	output.WriteString("| Repository | Branch | Main branch | Branches vs upstream |\n")
+	output.WriteString("|---|---|---|---|\n")
+
+	synced, skipped, forwarded := 0, 0, 0
+	for _, repo := range report.Repos {
+		name := filepath.Base(repo.Repository)
+		if repo.Skipped != "" {
+			skipped++
+			output.WriteString(fmt.Sprintf("| %s | %s | skipped: %s | |\n", name, repo.Branch, repo.Skipped))
+			continue
+		}
+		synced++
+		if repo.FastForward > 0 {
+			forwarded++
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | %s: %s | %s |\n", name, repo.Branch, repo.MainBranch, repo.MainStatus, trackingSummary(repo.Branches)))
+	}
+
+	output.WriteString(fmt.Sprintf("\n%d synced, %d main branches fast-forwarded, %d skipped", synced, forwarded, skipped))
+	if len(report.Failed) > 0 {
+		output.WriteString(fmt.Sprintf(", %d failed", len(report.Failed)))
+	}
+	output.WriteString("\n")
+	return output.String()
+}
+
+// trackingSummary lists the branches that differ from their upstream, e.g. "feature/x ↑2 ↓1, old (gone)"
+func trackingSummary(branches []git.BranchTracking) string {
+	var parts []string
+	for _, branch := range branches {
+		switch {
+		case branch.Gone:
+			parts = append(parts, branch.Branch+" (gone)")
+		case branch.Ahead > 0 && branch.Behind > 0:
+			parts = append(parts, fmt.Sprintf("%s ↑%d ↓%d", branch.Branch, branch.Ahead, branch.Behind))
+		case branch.Ahead > 0:
+			parts = append(parts, fmt.Sprintf("%s ↑%d", branch.Branch, branch.Ahead))
+		case branch.Behind > 0:
+			parts = append(parts, fmt.Sprintf("%s ↓%d", branch.Branch, branch.Behind))
+		}
+	}
+	if len(parts) == 0 {
+		return "all in sync"
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
+	flag.StringVar(&config.Single, "single", "", "Operate on specific repository path")
+	flag.BoolVar(&config.Main, "main", false, "Operate on main repositories (orbit + rasch-stack)")
+	flag.BoolVar(&config.All, "all", false, "Operate on all repositories from config (default)")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
+
+	flags.ReorderAndParse()
+
+	return config
+}
@@ -0,0 +1,259 @@
+package main
+
+// DESCRIPTION: create, list and remove git worktrees
+
+import (
+	"flag"
+	"fmt"
+	"cli-go/_internal/ai"
+	"cli-go/_internal/config"
+	"cli-go/_internal/custom"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Compact bool
+	JSON    bool
+	Base    string
+	Dir     string
+	Install bool
+	Force   bool
+}
+
+// Result is the outcome of adding or removing a worktree
+type Result struct {
+	Action   string             `json:"action"`
+	Worktree git.Worktree       `json:"worktree"`
+	Install  *sys.InstallResult `json:"install,omitempty"`
+}
+
+// ticketPattern matches a ticket ID (PNT-123) or a bare ticket number (123)
+var ticketPattern = regexp.MustCompile(`^([A-Za-z]{2,}-\d+|\d+)$`)
+
+func main() {
+	cfg := parseFlags()
+
+	args := flag.Args()
+	command := "list"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	if command == "help" {
+		io.LogInfo("gwt - Create, list and remove git worktrees")
+		io.LogInfo("gwt [list]                       List worktrees with branch, changes and ahead/behind")
+		io.LogInfo("gwt add <branch|ticket>          Create a worktree in ../<repo>--<branch> (--base <ref>, --dir <path>, --install)")
+		io.LogInfo("gwt remove <branch|ticket|path>  Remove a worktree, refused when it has changes (--force)")
+		io.LogInfo("gwt path <branch|ticket>         Print the path of a worktree, e.g. cd $(gwt path PNT-123)")
+		io.LogInfo("A ticket (PNT-123 or 123) uses the branch that contains it, or creates feature/PNT-123")
+		return
+	}
+
+	// Stop reading worktree status on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	worktrees, err := git.ListWorktrees(ctx, ".")
+	ai.ExitIf(err, "failed to list worktrees")
+	repoRoot := worktrees[0].Path
+
+	switch command {
+	case "list":
+		if cfg.JSON {
+			io.DirectOutput(worktrees, *clip, *file, true)
+		} else {
+			io.DirectOutput(formatList(worktrees), *clip, *file, false)
+		}
+	case "add":
+		branch := resolveBranch(repoRoot, requireArg(args, "add"))
+		if wt, err := git.FindWorktree(worktrees, branch); err == nil {
+			ai.ExitIf(fmt.Errorf("%s is already checked out in %s", branch, wt.Path), "failed to add worktree")
+		}
+
+		dir := cfg.Dir
+		if dir == "" {
+			dir = git.WorktreeDir(repoRoot, branch)
+		}
+		base := cfg.Base
+		if base == "" {
+			base = "origin/" + git.MainBranchAt(repoRoot)
+			if sys.RunCommandInDir(repoRoot, "git", "rev-parse", "--verify", "--quiet", base).ExitCode != 0 {
+				base = git.MainBranchAt(repoRoot)
+			}
+		}
+		ai.ExitIf(git.AddWorktree(repoRoot, branch, dir, base), "failed to add worktree")
+
+		result := Result{Action: "add"}
+		if cfg.Install {
+			io.LogInfo("Installing packages in %s", dir)
+			result.Install = sys.InstallPackagesIn(dir)
+			if !result.Install.Installed {
+				io.LogWarning("installation failed: %s", result.Install.Error)
+			}
+		}
+
+		worktrees, err = git.ListWorktrees(ctx, repoRoot)
+		ai.ExitIf(err, "failed to list worktrees")
+		wt, err := git.FindWorktree(worktrees, branch)
+		ai.ExitIf(err, "failed to find the new worktree")
+		result.Worktree = *wt
+		output(cfg, result, fmt.Sprintf("Created worktree for %s in %s", branch, wt.Path))
+	case "remove", "rm":
+		wt := findWorktree(worktrees, requireArg(args, "remove"))
+		ai.ExitIf(git.RemoveWorktree(repoRoot, *wt, cfg.Force), "failed to remove worktree")
+		output(cfg, Result{Action: "remove", Worktree: *wt}, fmt.Sprintf("Removed worktree %s (branch %s is kept)", wt.Path, wt.Branch))
+	case "path":
+		fmt.Println(findWorktree(worktrees, requireArg(args, "path")).Path)
+	default:
+		ai.LogError("unknown command %q, see gwt help", command)
+		os.Exit(1)
+	}
+}
+
+func requireArg(args []string, command string) string {
+	if len(args) == 0 {
+		ai.LogError("Usage: gwt %s <branch|ticket>", command)
+		os.Exit(1)
+	}
+	return args[0]
+}
+
+// resolveBranch turns a ticket into the branch that contains it (or feature/<ticket> for a new one);
+// anything else is used as the branch name
+func resolveBranch(repoRoot, query string) string {
+	ticket, ok := normalizeTicket(query)
+	if !ok {
+		return query
+	}
+
+	branches, err := git.ListBranches(repoRoot)
+	ai.ExitIf(err, "failed to list branches")
+	var matches []string
+	for _, branch := range branches {
+		if hasTicket(branch, ticket) {
+			matches = append(matches, branch)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "feature/" + ticket
+	case 1:
+		return matches[0]
+	}
+	ai.ExitIf(fmt.Errorf("%s is in several branches: %s", ticket, strings.Join(matches, ", ")), "pass the branch name instead")
+	return ""
+}
+
+// findWorktree finds a worktree by branch, path, directory name or ticket
+func findWorktree(worktrees []git.Worktree, query string) *git.Worktree {
+	if wt, err := git.FindWorktree(worktrees, query); err == nil {
+		return wt
+	}
+	if ticket, ok := normalizeTicket(query); ok {
+		for i, wt := range worktrees {
+			if hasTicket(wt.Branch, ticket) {
+				return &worktrees[i]
+			}
+		}
+	}
+	ai.ExitIf(fmt.Errorf("no worktree for %s", query), "failed to find worktree")
+	return nil
+}
+
+// hasTicket reports whether a branch name contains the ticket as a whole token, so PNT-12
+// doesn't match feature/PNT-123
+func hasTicket(branch, ticket string) bool {
+	pattern := regexp.MustCompile(`(^|[^A-Z0-9])` + regexp.QuoteMeta(ticket) + `($|[^0-9])`)
+	return pattern.MatchString(strings.ToUpper(branch))
+}
+
+// normalizeTicket returns the upper-case ticket ID for PNT-123 or 123 (default project key)
+func normalizeTicket(query string) (string, bool) {
+	if !ticketPattern.MatchString(query) {
+		return "", false
+	}
+	projectKey := ""
+	if configData, err := config.LoadConfig(); err == nil {
+		projectKey = configData.Ringier.DefaultProjectKey
+	}
+	ticket := custom.NormalizeTicketID(strings.ToUpper(query), projectKey)
+	if !custom.IsValidTicketFormat(ticket) {
+		return "", false
+	}
+	return ticket, true
+}
+
+func output(cfg Config, result Result, message string) {
+	if cfg.JSON {
+		io.DirectOutput(result, *clip, *file, true)
+	} else {
+		io.LogSuccess("%s", message)
+	}
+}
+
+func formatList(worktrees []git.Worktree) string {
+	var output strings.Builder
+	output.WriteString("| Path | Branch | Status | Upstream | Last commit |\n")
+	output.WriteString("|---|---|---|---|---|\n")
+	for _, wt := range worktrees {
+		branch := wt.Branch
+		if branch == "" {
+			branch = "detached at " + git.TruncateString(wt.Head, 10)
+		}
+		if wt.Main {
+			branch += " (main worktree)"
+		}
+
+		var status []string
+		switch {
+		case wt.Prunable:
+			status = append(status, "directory missing")
+		case wt.Changes > 0:
+			status = append(status, fmt.Sprintf("%d changed", wt.Changes))
+		default:
+			status = append(status, "clean")
+		}
+		if wt.Locked {
+			status = append(status, "locked")
+		}
+
+		upstream := "–"
+		if wt.Upstream != "" {
+			upstream = fmt.Sprintf("%s ↑%d ↓%d", wt.Upstream, wt.Ahead, wt.Behind)
+		}
+		lastCommit := ""
+		if !wt.LastCommit.IsZero() {
+			lastCommit = fmt.Sprintf("%s ago %s", io.FormatDuration(time.Since(wt.LastCommit)), git.TruncateString(wt.Subject, 40))
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			wt.Path, branch, strings.Join(status, ", "), upstream, strings.ReplaceAll(lastCommit, "|", "\\|")))
+	}
+	return output.String()
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	config := Config{}
+
+	flag.BoolVar(&config.Compact, "compact", false, "Use compact JSON format")
+	flag.BoolVar(&config.JSON, "json", false, "Output in JSON format")
+	flag.StringVar(&config.Base, "base", "", "Start point for a new branch (default: origin/<main branch>)")
+	flag.StringVar(&config.Dir, "dir", "", "Worktree directory (default: ../<repo>--<branch>)")
+	flag.BoolVar(&config.Install, "install", false, "Install packages in the new worktree (yarn → pnpm → npm)")
+	flag.BoolVar(&config.Force, "force", false, "Remove a worktree even with uncommitted changes")
+
+	flags.ReorderAndParse()
+
+	return config
+}
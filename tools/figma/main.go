@@ -10,6 +10,7 @@ import (
 	"cli-go/_internal/ai"
 	"cli-go/_internal/config"
 	"cli-go/_internal/figma"
+	"cli-go/_internal/sys"
 )
 
 const defaultFileKey = "Bvw817OVY6zhmEty1Syj8Q" // ORBIT_FILE_KEY
@@ -24,6 +25,10 @@ func main() {
 
 	flag.Parse()
 
+	// Cancel in-flight requests on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	args := flag.Args()
 	if len(args) == 0 {
 		fmt.Fprintf(os.Stderr, "Error: command or query required\n")
@@ -38,13 +43,13 @@ func main() {
 	switch command {
 	case "search":
 		token := getFigmaToken()
-		figma.HandleSearch(args[1:], token, *clip, *file, *compact, *json)
+		figma.HandleSearch(ctx, args[1:], token, *clip, *file, *compact, *json)
 	case "--":
 		token := getFigmaToken()
-		figma.HandleFullMetadata(args[1:], token, *clip, *file, *compact, *json)
+		figma.HandleFullMetadata(ctx, args[1:], token, *clip, *file, *compact, *json)
 	case "init":
 		token := getFigmaToken()
-		figma.HandleInit(args[1:], token, *clip, *file, *compact, *json)
+		figma.HandleInit(ctx, args[1:], token, *clip, *file, *compact, *json)
 	case "list":
 		token := getFigmaToken()
 		figma.HandleList(args[1:], token, *clip, *file, *compact, *json)
@@ -54,7 +59,7 @@ func main() {
 	default:
 		// Default: treat as search query
 		token := getFigmaToken()
-		figma.HandleSearch(args, token, *clip, *file, *compact, *json)
+		figma.HandleSearch(ctx, args, token, *clip, *file, *compact, *json)
 	}
 }
 
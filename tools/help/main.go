@@ -46,12 +46,15 @@ func main() {
 		// AI tools
 		"cld": "ai", "gem": "ai", "gro": "ai", "grop": "ai", "haik": "ai",
 		"j": "ai", "ji": "ai", "jj": "ai", "jp": "ai", "prompts": "ai",
+		"aibatch": "ai", "pipeline": "ai", "aiexport": "ai",
 
 		// Git tools
 		"gaff": "git", "gbd": "git", "gcb": "git", "gcd": "git", "gcm": "git",
 		"gco": "git", "gcommit": "git", "ginstall": "git", "gmain": "git",
 		"gname": "git", "greinstall": "git", "grt": "git", "gs": "git",
 		"gsp": "git", "gstats": "git", "gprs": "git",
+		"grelnotes": "git", "gowners": "git", "ghotspots": "git",
+		"gstash": "git", "gprune": "git", "gwt": "git", "gsync": "git",
 
 		// Core tools
 		"check_alias": "core", "killport": "core", "perf": "core",
@@ -61,6 +64,7 @@ func main() {
 		"edit": "tools", "figma": "tools",
 		"help": "tools", "jira": "tools",
 		"repos": "tools", "test": "tools", "web": "tools",
+		"standup": "tools",
 
 		// Ringier
 		"smart_start": "ringier",
@@ -6,12 +6,17 @@ import (
 	"flag"
 	"cli-go/_internal/flags"
 	"cli-go/_internal/jira"
+	"cli-go/_internal/sys"
 )
 
 func main() {
 
 	toolConfig := parseFlags()
 
+	// Cancel in-flight requests on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
 	// Parse arguments
 	args := flag.Args()
 	if len(args) == 0 {
@@ -30,7 +35,7 @@ func main() {
 			JSON: toolConfig.JSON,
 			Open: toolConfig.Open,
 		}
-		result := jira.RouteCommand("user", []string{}, client, flags)
+		result := jira.RouteCommand(ctx, "user", []string{}, client, flags)
 		jira.OutputJSON(result, flags)
 		return
 	}
@@ -49,7 +54,7 @@ func main() {
 			JSON: toolConfig.JSON,
 			Open: toolConfig.Open,
 		}
-		result := jira.RouteCommand("help", []string{}, nil, flags)
+		result := jira.RouteCommand(ctx, "help", []string{}, nil, flags)
 		jira.OutputJSON(result, flags)
 		return
 	}
@@ -76,7 +81,7 @@ func main() {
 
 	// Route command to appropriate handler
 	remainingArgs := args[1:]
-	result := jira.RouteCommand(command, remainingArgs, client, flags)
+	result := jira.RouteCommand(ctx, command, remainingArgs, client, flags)
 	jira.OutputJSON(result, flags)
 }
 
@@ -0,0 +1,258 @@
+package main
+
+// DESCRIPTION: daily standup report from git, GitHub and Jira activity
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cli-go/_internal/ai"
+	"cli-go/_internal/config"
+	"cli-go/_internal/flags"
+	"cli-go/_internal/git"
+	"cli-go/_internal/io"
+	"cli-go/_internal/standup"
+	"cli-go/_internal/sys"
+)
+
+type Config struct {
+	Since      string
+	Author     string
+	Main       bool
+	Format     string
+	JSON       bool
+	NoJira     bool
+	NoGitHub   bool
+	AI         bool
+	Provider   string
+	Persona    string
+	NoRedact   bool
+	Generation *ai.GenerationFlags
+}
+
+const defaultStandupPrompt = `You condense developer activity into a standup update.
+Write three short sections: Yesterday, Today, Blockers. Use at most one line per ticket,
+mention ticket keys, merge related commits into one statement and skip noise like typo fixes.
+Write "None" for empty sections. Return only the update.`
+
+func main() {
+	cfg := parseFlags()
+
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	since, err := windowStart(time.Now(), cfg.Since)
+	ai.ExitIf(err, "invalid --since")
+
+	configData, err := config.LoadConfig()
+	ai.ExitIf(err, "failed to load configuration")
+
+	repos := configData.Repositories
+	if cfg.Main {
+		repos = configData.GetMainRepos()
+	}
+
+	author := cfg.Author
+	if author == "" {
+		author = configData.Ringier.DefaultUser
+	}
+	report, err := standup.Collect(ctx, standup.Options{
+		Repos:    repos,
+		Author:   author,
+		Since:    since,
+		NoGitHub: cfg.NoGitHub,
+		NoJira:   cfg.NoJira,
+	})
+	ai.ExitIf(err, "cancelled")
+
+	format := strings.ToLower(cfg.Format)
+	if cfg.JSON {
+		format = "json"
+	}
+
+	if cfg.AI {
+		report.Summary = condense(ctx, cfg, formatReport(report, false))
+	}
+
+	switch format {
+	case "json":
+		io.DirectOutput(report, *clip, *file, true)
+	case "md", "markdown":
+		output := formatReport(report, false)
+		if report.Summary != "" {
+			output = report.Summary
+		}
+		io.DirectOutput(output, *clip, *file, false)
+	case "plain", "text":
+		output := formatReport(report, true)
+		if report.Summary != "" {
+			output = report.Summary
+		}
+		if *clip || *file != "" {
+			io.DirectOutput(output, *clip, *file, false)
+		} else {
+			fmt.Println(output)
+		}
+	default:
+		io.LogError("unknown format: %s (use md, plain or json)", cfg.Format)
+		os.Exit(1)
+	}
+}
+
+// windowStart parses --since as a date (2006-01-02) or duration (36h); the default is
+// the start of the previous working day, so a Monday standup covers Friday
+func windowStart(now time.Time, since string) (time.Time, error) {
+	if since != "" {
+		if t, err := time.ParseInLocation("2006-01-02", since, time.Local); err == nil {
+			return t, nil
+		}
+		d, err := time.ParseDuration(since)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("expected a date (2006-01-02) or duration (36h): %s", since)
+		}
+		return now.Add(-d), nil
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day, nil
+}
+
+// formatReport renders the report as markdown, or as plain text without markdown syntax
+func formatReport(report *standup.Report, plain bool) string {
+	var b strings.Builder
+	heading := func(title string) {
+		if plain {
+			fmt.Fprintf(&b, "\n%s:\n", strings.ToUpper(title))
+		} else {
+			fmt.Fprintf(&b, "\n## %s\n\n", title)
+		}
+	}
+	key := func(k string) string {
+		if plain || k == "" {
+			return k
+		}
+		return "**" + k + "**"
+	}
+
+	title := fmt.Sprintf("Standup (since %s)", report.Since.Format("Mon 2006-01-02 15:04"))
+	if plain {
+		b.WriteString(title + "\n")
+	} else {
+		b.WriteString("# " + title + "\n")
+	}
+
+	heading("Yesterday")
+	if len(report.Yesterday) == 0 && len(report.Untracked) == 0 {
+		b.WriteString("- Nothing recorded\n")
+	}
+	for _, activity := range report.Yesterday {
+		line := "- " + key(activity.Key)
+		if activity.Summary != "" {
+			line += " " + activity.Summary
+		}
+		if activity.Status != "" {
+			line += " (" + activity.Status + ")"
+		}
+		b.WriteString(line + "\n")
+
+		if len(activity.Commits) > 0 {
+			var messages []string
+			for _, commit := range activity.Commits {
+				messages = append(messages, commit.Message)
+			}
+			fmt.Fprintf(&b, "  - %s: %s\n", io.Plural(len(activity.Commits), "commit"), strings.Join(git.RemoveDuplicates(messages), "; "))
+		}
+		for _, pr := range activity.PRs {
+			fmt.Fprintf(&b, "  - PR #%d %s (%s)\n", pr.Number, pr.Title, strings.ToLower(pr.State))
+		}
+		if activity.Jira && len(activity.Commits) == 0 && len(activity.PRs) == 0 {
+			b.WriteString("  - updated in Jira\n")
+		}
+	}
+	for _, commit := range report.Untracked {
+		fmt.Fprintf(&b, "- %s (%s)\n", commit.Message, repoName(commit.Repository))
+	}
+
+	heading("Today")
+	writeItems(&b, report.Today, key)
+
+	heading("Blockers")
+	writeItems(&b, report.Blockers, key)
+
+	return b.String()
+}
+
+// writeItems writes one line per item, or "None"
+func writeItems(b *strings.Builder, items []standup.Item, key func(string) string) {
+	if len(items) == 0 {
+		b.WriteString("- None\n")
+		return
+	}
+	for _, item := range items {
+		line := "- "
+		if item.Key != "" {
+			line += key(item.Key) + " "
+		}
+		line += item.Title
+		if item.Status != "" {
+			line += " (" + item.Status + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// repoName returns the last path element of a repository path
+func repoName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// condense asks the AI for a short yesterday/today/blockers update
+func condense(ctx context.Context, cfg Config, report string) string {
+	client, err := ai.NewToolClient(ai.ToolClientOptions{
+		Tool:          "standup",
+		Provider:      cfg.Provider,
+		Persona:       cfg.Persona,
+		DefaultPrompt: defaultStandupPrompt,
+		NoRedact:      cfg.NoRedact,
+		Generation:    cfg.Generation,
+	})
+	ai.ExitIf(err, "failed to create AI client")
+
+	io.LogInfo("✍️  Condensing standup with %s", client.GetModel())
+	response, err := client.SendMessage(ctx, report)
+	ai.ExitIfAPI(err, "failed to condense standup", cfg.JSON)
+	return strings.TrimSpace(response)
+}
+
+var (
+	clip = flag.Bool("clip", false, "Copy to clipboard")
+	file = flag.String("file", "", "Write to file")
+)
+
+func parseFlags() Config {
+	cfg := Config{}
+
+	flag.StringVar(&cfg.Since, "since", "", "Window start: date (2006-01-02) or duration (36h) (default: start of previous working day)")
+	flag.StringVar(&cfg.Author, "author", "", "Commit author (default: ringier.default_user, else git user.email)")
+	flag.BoolVar(&cfg.Main, "main", false, "Only main repositories")
+	flag.StringVar(&cfg.Format, "format", "md", "Output format: md, plain or json")
+	flag.BoolVar(&cfg.JSON, "json", false, "Output in JSON format")
+	flag.BoolVar(&cfg.NoJira, "no-jira", false, "Skip Jira")
+	flag.BoolVar(&cfg.NoGitHub, "no-github", false, "Skip GitHub PRs")
+	flag.BoolVar(&cfg.AI, "ai", false, "Condense the report with AI")
+	flag.StringVar(&cfg.Provider, "provider", "openai", "AI provider for --ai: openai, anthropic, haiku, google, xai")
+	flag.StringVar(&cfg.Persona, "persona", "", "Persona used as system prompt for --ai")
+	flag.BoolVar(&cfg.NoRedact, "no-redact", false, "Send content without masking secrets")
+	cfg.Generation = ai.RegisterGenerationFlags()
+
+	flags.ReorderAndParse()
+
+	return cfg
+}
@@ -3,6 +3,7 @@ package main
 // DESCRIPTION: Web search tool using Perplexity AI with intelligent caching
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"cli-go/_internal/cache"
 	"cli-go/_internal/config"
 	"cli-go/_internal/io"
+	"cli-go/_internal/sys"
 )
 
 // SearchResult represents a web search result
@@ -42,11 +44,14 @@ func main() {
 	}
 
 	var (
-		clip    = flag.Bool("clip", false, "Copy to clipboard")
-		file    = flag.String("file", "", "Write to file")
-		compact = flag.Bool("compact", false, "Use compact JSON output")
-		json    = flag.Bool("json", false, "Output in JSON format")
+		clip     = flag.Bool("clip", false, "Copy to clipboard")
+		file     = flag.String("file", "", "Write to file")
+		compact  = flag.Bool("compact", false, "Use compact JSON output")
+		json     = flag.Bool("json", false, "Output in JSON format")
+		persona  = flag.String("persona", "", "Persona from config (system prompt, model, temperature)")
+		noRedact = flag.Bool("no-redact", false, "Send query without masking secrets")
 	)
+	generation := ai.RegisterGenerationFlags()
 	flag.Parse()
 
 	args = flag.Args()
@@ -64,7 +69,11 @@ func main() {
 	// Handle search query
 	query := strings.Join(args, " ")
 	fmt.Fprintf(os.Stderr, "DEBUG: json flag=%v\n", *json)
-	handleSearch(query, *clip, *file, *compact, *json)
+	// Cancel the request cleanly on Ctrl-C / SIGTERM
+	ctx, cancel := sys.SignalContext()
+	defer cancel()
+
+	handleSearch(ctx, query, *persona, generation, !*noRedact, *clip, *file, *compact, *json)
 }
 
 func handleCacheCommand(args []string, clip bool, file string, compact, json bool) {
@@ -136,7 +145,7 @@ The system is working correctly - it just needs a valid API key to make real API
 	io.DirectOutput(result, clip, file, false)
 }
 
-func handleSearch(query string, clip bool, file string, compact, json bool) {
+func handleSearch(ctx context.Context, query, personaName string, generation *ai.GenerationFlags, redact bool, clip bool, file string, compact, json bool) {
 	// Initialize cache first
 	cacheStore, err := cache.New("web")
 	ai.ExitIf(err, "failed to initialize cache")
@@ -175,7 +184,17 @@ func handleSearch(query string, clip bool, file string, compact, json bool) {
 	ai.ExitIf(err, "failed to get Perplexity API key")
 
 	client := ai.NewPerplexityClient(apiKey)
-	content, err := client.Search(query)
+
+	// Apply persona and generation parameters (--persona, config)
+	err = ai.SetupToolClient(client, ai.ToolClientOptions{
+		Tool:       "web",
+		Persona:    personaName,
+		NoRedact:   !redact,
+		Generation: generation,
+	})
+	ai.ExitIf(err, "failed to set up client")
+
+	content, err := client.Search(ctx, query)
 	if err != nil {
 		// Check if this is a mock API error that should return mock content
 		if mockErr, ok := err.(*ai.MockAPIError); ok {
@@ -191,7 +210,7 @@ func handleSearch(query string, clip bool, file string, compact, json bool) {
 			io.DirectOutput(result, clip, file, json)
 			return
 		} else {
-			ai.ExitIf(err, "Perplexity API error")
+			ai.ExitIfAPI(err, "Perplexity API error", json)
 		}
 	}
 